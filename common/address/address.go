@@ -0,0 +1,14 @@
+// Package address describes where an order is delivered to.
+package address
+
+// Address is a delivery address with the coordinates couriers navigate to.
+type Address struct {
+	Street     string  `json:"street" validate:"required,max=200"`
+	Apartment  string  `json:"apartment,omitempty" validate:"max=50"`
+	City       string  `json:"city" validate:"required,max=100"`
+	PostalCode string  `json:"postal_code,omitempty" validate:"max=20"`
+	Latitude   float64 `json:"latitude" validate:"gte=-90,lte=90"`
+	Longitude  float64 `json:"longitude" validate:"gte=-180,lte=180"`
+	// Instructions are notes for the courier, e.g. the door code.
+	Instructions string `json:"instructions,omitempty" validate:"max=500"`
+}
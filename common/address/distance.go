@@ -0,0 +1,22 @@
+package address
+
+import "math"
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+// DistanceKm is the great-circle distance between two points given in
+// degrees.
+func DistanceKm(fromLatitude, fromLongitude, toLatitude, toLongitude float64) float64 {
+	lat1, lat2 := radians(fromLatitude), radians(toLatitude)
+	dLat := lat2 - lat1
+	dLng := radians(toLongitude - fromLongitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
@@ -0,0 +1,94 @@
+package hours
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// restaurants may use any IANA timezone, and the service images ship without tzdata
+	_ "time/tzdata"
+)
+
+var ErrInvalidHours = errors.New("invalid opening hours")
+
+const clockLayout = "15:04"
+
+// Period is one opening interval of a weekday, with opening and closing
+// clock times as "HH:MM". A period that closes at or before it opens ends on
+// the next day, so "00:00" closes at midnight.
+type Period struct {
+	Weekday time.Weekday `json:"weekday" validate:"gte=0,lte=6"`
+	Opens   string       `json:"opens" validate:"required"`
+	Closes  string       `json:"closes" validate:"required"`
+}
+
+// Week is the weekly opening hours of a restaurant. A restaurant without any
+// periods has no opening hours set and is treated as always open.
+type Week []Period
+
+func (w Week) Validate() error {
+	for _, p := range w {
+		opens, err := time.Parse(clockLayout, p.Opens)
+		if err != nil {
+			return fmt.Errorf("%w: opens %q is not HH:MM", ErrInvalidHours, p.Opens)
+		}
+		closes, err := time.Parse(clockLayout, p.Closes)
+		if err != nil {
+			return fmt.Errorf("%w: closes %q is not HH:MM", ErrInvalidHours, p.Closes)
+		}
+		if opens.Equal(closes) {
+			return fmt.Errorf("%w: period on %s opens and closes at %s", ErrInvalidHours, p.Weekday, p.Opens)
+		}
+	}
+
+	return nil
+}
+
+// IsOpenAt reports whether t falls into one of the periods, read as wall
+// clock times in loc.
+func (w Week) IsOpenAt(t time.Time, loc *time.Location) bool {
+	if len(w) == 0 {
+		return true
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	previousDay := (local.Weekday() + 6) % 7
+
+	for _, p := range w {
+		opens, closes, ok := p.minutes()
+		if !ok {
+			continue
+		}
+
+		if opens < closes {
+			if p.Weekday == local.Weekday() && minute >= opens && minute < closes {
+				return true
+			}
+			continue
+		}
+
+		// overnight period: the evening of its weekday and the morning after
+		if p.Weekday == local.Weekday() && minute >= opens {
+			return true
+		}
+		if p.Weekday == previousDay && minute < closes {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p Period) minutes() (opens, closes int, ok bool) {
+	o, err := time.Parse(clockLayout, p.Opens)
+	if err != nil {
+		return 0, 0, false
+	}
+	c, err := time.Parse(clockLayout, p.Closes)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return o.Hour()*60 + o.Minute(), c.Hour()*60 + c.Minute(), true
+}
@@ -25,6 +25,10 @@ type OrderServiceClient interface {
 	GetOrderOwner(ctx context.Context, in *GetOrderOwnerRequest, opts ...grpc.CallOption) (*GetOrderOwnerResponce, error)
 	GetRetryOrders(ctx context.Context, in *GetRetryOrdersRequest, opts ...grpc.CallOption) (*GetRetryOrdersResponce, error)
 	GetOrderStatus(ctx context.Context, in *GetOrderStatusRequest, opts ...grpc.CallOption) (*GetOrderStatusResponce, error)
+	GetOrderRestaurant(ctx context.Context, in *GetOrderRestaurantRequest, opts ...grpc.CallOption) (*GetOrderRestaurantResponce, error)
+	GetDueScheduledOrders(ctx context.Context, in *GetDueScheduledOrdersRequest, opts ...grpc.CallOption) (*GetDueScheduledOrdersResponce, error)
+	GetExpiredUnpaidOrders(ctx context.Context, in *GetExpiredUnpaidOrdersRequest, opts ...grpc.CallOption) (*GetExpiredUnpaidOrdersResponce, error)
+	GetOrderForPayment(ctx context.Context, in *GetOrderForPaymentRequest, opts ...grpc.CallOption) (*GetOrderForPaymentResponce, error)
 }
 
 type orderServiceClient struct {
@@ -62,6 +66,42 @@ func (c *orderServiceClient) GetOrderStatus(ctx context.Context, in *GetOrderSta
 	return out, nil
 }
 
+func (c *orderServiceClient) GetOrderRestaurant(ctx context.Context, in *GetOrderRestaurantRequest, opts ...grpc.CallOption) (*GetOrderRestaurantResponce, error) {
+	out := new(GetOrderRestaurantResponce)
+	err := c.cc.Invoke(ctx, "/orders.OrderService/GetOrderRestaurant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetDueScheduledOrders(ctx context.Context, in *GetDueScheduledOrdersRequest, opts ...grpc.CallOption) (*GetDueScheduledOrdersResponce, error) {
+	out := new(GetDueScheduledOrdersResponce)
+	err := c.cc.Invoke(ctx, "/orders.OrderService/GetDueScheduledOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetExpiredUnpaidOrders(ctx context.Context, in *GetExpiredUnpaidOrdersRequest, opts ...grpc.CallOption) (*GetExpiredUnpaidOrdersResponce, error) {
+	out := new(GetExpiredUnpaidOrdersResponce)
+	err := c.cc.Invoke(ctx, "/orders.OrderService/GetExpiredUnpaidOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) GetOrderForPayment(ctx context.Context, in *GetOrderForPaymentRequest, opts ...grpc.CallOption) (*GetOrderForPaymentResponce, error) {
+	out := new(GetOrderForPaymentResponce)
+	err := c.cc.Invoke(ctx, "/orders.OrderService/GetOrderForPayment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -69,6 +109,10 @@ type OrderServiceServer interface {
 	GetOrderOwner(context.Context, *GetOrderOwnerRequest) (*GetOrderOwnerResponce, error)
 	GetRetryOrders(context.Context, *GetRetryOrdersRequest) (*GetRetryOrdersResponce, error)
 	GetOrderStatus(context.Context, *GetOrderStatusRequest) (*GetOrderStatusResponce, error)
+	GetOrderRestaurant(context.Context, *GetOrderRestaurantRequest) (*GetOrderRestaurantResponce, error)
+	GetDueScheduledOrders(context.Context, *GetDueScheduledOrdersRequest) (*GetDueScheduledOrdersResponce, error)
+	GetExpiredUnpaidOrders(context.Context, *GetExpiredUnpaidOrdersRequest) (*GetExpiredUnpaidOrdersResponce, error)
+	GetOrderForPayment(context.Context, *GetOrderForPaymentRequest) (*GetOrderForPaymentResponce, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -85,6 +129,18 @@ func (UnimplementedOrderServiceServer) GetRetryOrders(context.Context, *GetRetry
 func (UnimplementedOrderServiceServer) GetOrderStatus(context.Context, *GetOrderStatusRequest) (*GetOrderStatusResponce, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderStatus not implemented")
 }
+func (UnimplementedOrderServiceServer) GetOrderRestaurant(context.Context, *GetOrderRestaurantRequest) (*GetOrderRestaurantResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderRestaurant not implemented")
+}
+func (UnimplementedOrderServiceServer) GetDueScheduledOrders(context.Context, *GetDueScheduledOrdersRequest) (*GetDueScheduledOrdersResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDueScheduledOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) GetExpiredUnpaidOrders(context.Context, *GetExpiredUnpaidOrdersRequest) (*GetExpiredUnpaidOrdersResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExpiredUnpaidOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) GetOrderForPayment(context.Context, *GetOrderForPaymentRequest) (*GetOrderForPaymentResponce, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderForPayment not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +208,78 @@ func _OrderService_GetOrderStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_GetOrderRestaurant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRestaurantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderRestaurant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.OrderService/GetOrderRestaurant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderRestaurant(ctx, req.(*GetOrderRestaurantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetDueScheduledOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDueScheduledOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetDueScheduledOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.OrderService/GetDueScheduledOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetDueScheduledOrders(ctx, req.(*GetDueScheduledOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetExpiredUnpaidOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExpiredUnpaidOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetExpiredUnpaidOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.OrderService/GetExpiredUnpaidOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetExpiredUnpaidOrders(ctx, req.(*GetExpiredUnpaidOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_GetOrderForPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderForPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderForPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.OrderService/GetOrderForPayment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderForPayment(ctx, req.(*GetOrderForPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +299,22 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderStatus",
 			Handler:    _OrderService_GetOrderStatus_Handler,
 		},
+		{
+			MethodName: "GetOrderRestaurant",
+			Handler:    _OrderService_GetOrderRestaurant_Handler,
+		},
+		{
+			MethodName: "GetDueScheduledOrders",
+			Handler:    _OrderService_GetDueScheduledOrders_Handler,
+		},
+		{
+			MethodName: "GetExpiredUnpaidOrders",
+			Handler:    _OrderService_GetExpiredUnpaidOrders_Handler,
+		},
+		{
+			MethodName: "GetOrderForPayment",
+			Handler:    _OrderService_GetOrderForPayment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/orders.proto",
@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.28.1
 // 	protoc        v5.29.3
-// source: common/proto/restaurants.proto
+// source: proto/restaurants.proto
 
 package proto
 
@@ -32,7 +32,7 @@ type GetMenuItemsRequest struct {
 func (x *GetMenuItemsRequest) Reset() {
 	*x = GetMenuItemsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_restaurants_proto_msgTypes[0]
+		mi := &file_proto_restaurants_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -45,7 +45,7 @@ func (x *GetMenuItemsRequest) String() string {
 func (*GetMenuItemsRequest) ProtoMessage() {}
 
 func (x *GetMenuItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_restaurants_proto_msgTypes[0]
+	mi := &file_proto_restaurants_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +58,7 @@ func (x *GetMenuItemsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMenuItemsRequest.ProtoReflect.Descriptor instead.
 func (*GetMenuItemsRequest) Descriptor() ([]byte, []int) {
-	return file_common_proto_restaurants_proto_rawDescGZIP(), []int{0}
+	return file_proto_restaurants_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *GetMenuItemsRequest) GetRestaurantId() string {
@@ -86,7 +86,7 @@ type GetMenuItemsResponse struct {
 func (x *GetMenuItemsResponse) Reset() {
 	*x = GetMenuItemsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_restaurants_proto_msgTypes[1]
+		mi := &file_proto_restaurants_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -99,7 +99,7 @@ func (x *GetMenuItemsResponse) String() string {
 func (*GetMenuItemsResponse) ProtoMessage() {}
 
 func (x *GetMenuItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_restaurants_proto_msgTypes[1]
+	mi := &file_proto_restaurants_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -112,7 +112,7 @@ func (x *GetMenuItemsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMenuItemsResponse.ProtoReflect.Descriptor instead.
 func (*GetMenuItemsResponse) Descriptor() ([]byte, []int) {
-	return file_common_proto_restaurants_proto_rawDescGZIP(), []int{1}
+	return file_proto_restaurants_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GetMenuItemsResponse) GetMenuItems() []*MenuItem {
@@ -127,15 +127,16 @@ type MenuItem struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id    string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name  string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price       *Money `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 }
 
 func (x *MenuItem) Reset() {
 	*x = MenuItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_common_proto_restaurants_proto_msgTypes[2]
+		mi := &file_proto_restaurants_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -148,7 +149,7 @@ func (x *MenuItem) String() string {
 func (*MenuItem) ProtoMessage() {}
 
 func (x *MenuItem) ProtoReflect() protoreflect.Message {
-	mi := &file_common_proto_restaurants_proto_msgTypes[2]
+	mi := &file_proto_restaurants_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -161,7 +162,7 @@ func (x *MenuItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
 func (*MenuItem) Descriptor() ([]byte, []int) {
-	return file_common_proto_restaurants_proto_rawDescGZIP(), []int{2}
+	return file_proto_restaurants_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *MenuItem) GetId() string {
@@ -178,83 +179,97 @@ func (x *MenuItem) GetName() string {
 	return ""
 }
 
-func (x *MenuItem) GetPrice() float64 {
+func (x *MenuItem) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
+}
+
+func (x *MenuItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-var File_common_proto_restaurants_proto protoreflect.FileDescriptor
-
-var file_common_proto_restaurants_proto_rawDesc = []byte{
-	0x0a, 0x1e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72,
-	0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x22, 0x5e, 0x0a,
-	0x13, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61,
-	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73,
-	0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x65, 0x6e,
-	0x75, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0b, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x73, 0x22, 0x4c, 0x0a,
-	0x14, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x73, 0x74,
-	0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x2e, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d,
-	0x52, 0x09, 0x6d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x44, 0x0a, 0x08, 0x4d,
-	0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70,
-	0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63,
-	0x65, 0x32, 0x68, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e,
-	0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x20, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72,
-	0x61, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61,
+var File_proto_restaurants_proto protoreflect.FileDescriptor
+
+var file_proto_restaurants_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61,
+	0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6f,
+	0x6e, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5e, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72,
+	0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x74,
+	0x65, 0x6d, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65,
+	0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x73, 0x22, 0x4c, 0x0a, 0x14, 0x47, 0x65, 0x74,
+	0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x34, 0x0a, 0x0a, 0x6d, 0x65, 0x6e, 0x75, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61,
+	0x6e, 0x74, 0x73, 0x2e, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x6d, 0x65,
+	0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x7a, 0x0a, 0x08, 0x4d, 0x65, 0x6e, 0x75, 0x49,
+	0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x6e, 0x65, 0x79, 0x2e, 0x4d,
+	0x6f, 0x6e, 0x65, 0x79, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4a, 0x04, 0x08,
+	0x03, 0x10, 0x04, 0x32, 0x68, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x74, 0x61, 0x75, 0x72, 0x61, 0x6e,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4d,
+	0x65, 0x6e, 0x75, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x20, 0x2e, 0x72, 0x65, 0x73, 0x74, 0x61,
 	0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e, 0x75, 0x49, 0x74,
-	0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x39, 0x5a, 0x37, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x54, 0x77, 0x69,
-	0x78, 0x2f, 0x46, 0x6f, 0x6f, 0x64, 0x2d, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x2d,
-	0x41, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x72, 0x65, 0x73,
+	0x74, 0x61, 0x75, 0x72, 0x61, 0x6e, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x6e, 0x75,
+	0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x39, 0x5a,
+	0x37, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x74, 0x54,
+	0x77, 0x69, 0x78, 0x2f, 0x46, 0x6f, 0x6f, 0x64, 0x2d, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x2d, 0x41, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2f, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_common_proto_restaurants_proto_rawDescOnce sync.Once
-	file_common_proto_restaurants_proto_rawDescData = file_common_proto_restaurants_proto_rawDesc
+	file_proto_restaurants_proto_rawDescOnce sync.Once
+	file_proto_restaurants_proto_rawDescData = file_proto_restaurants_proto_rawDesc
 )
 
-func file_common_proto_restaurants_proto_rawDescGZIP() []byte {
-	file_common_proto_restaurants_proto_rawDescOnce.Do(func() {
-		file_common_proto_restaurants_proto_rawDescData = protoimpl.X.CompressGZIP(file_common_proto_restaurants_proto_rawDescData)
+func file_proto_restaurants_proto_rawDescGZIP() []byte {
+	file_proto_restaurants_proto_rawDescOnce.Do(func() {
+		file_proto_restaurants_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_restaurants_proto_rawDescData)
 	})
-	return file_common_proto_restaurants_proto_rawDescData
+	return file_proto_restaurants_proto_rawDescData
 }
 
-var file_common_proto_restaurants_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_common_proto_restaurants_proto_goTypes = []interface{}{
+var file_proto_restaurants_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_restaurants_proto_goTypes = []interface{}{
 	(*GetMenuItemsRequest)(nil),  // 0: restaurants.GetMenuItemsRequest
 	(*GetMenuItemsResponse)(nil), // 1: restaurants.GetMenuItemsResponse
 	(*MenuItem)(nil),             // 2: restaurants.MenuItem
+	(*Money)(nil),                // 3: money.Money
 }
-var file_common_proto_restaurants_proto_depIdxs = []int32{
+var file_proto_restaurants_proto_depIdxs = []int32{
 	2, // 0: restaurants.GetMenuItemsResponse.menu_items:type_name -> restaurants.MenuItem
-	0, // 1: restaurants.RestaurantService.GetMenuItems:input_type -> restaurants.GetMenuItemsRequest
-	1, // 2: restaurants.RestaurantService.GetMenuItems:output_type -> restaurants.GetMenuItemsResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3, // 1: restaurants.MenuItem.price:type_name -> money.Money
+	0, // 2: restaurants.RestaurantService.GetMenuItems:input_type -> restaurants.GetMenuItemsRequest
+	1, // 3: restaurants.RestaurantService.GetMenuItems:output_type -> restaurants.GetMenuItemsResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
-func init() { file_common_proto_restaurants_proto_init() }
-func file_common_proto_restaurants_proto_init() {
-	if File_common_proto_restaurants_proto != nil {
+func init() { file_proto_restaurants_proto_init() }
+func file_proto_restaurants_proto_init() {
+	if File_proto_restaurants_proto != nil {
 		return
 	}
+	file_proto_money_proto_init()
 	if !protoimpl.UnsafeEnabled {
-		file_common_proto_restaurants_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_restaurants_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetMenuItemsRequest); i {
 			case 0:
 				return &v.state
@@ -266,7 +281,7 @@ func file_common_proto_restaurants_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_restaurants_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_restaurants_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetMenuItemsResponse); i {
 			case 0:
 				return &v.state
@@ -278,7 +293,7 @@ func file_common_proto_restaurants_proto_init() {
 				return nil
 			}
 		}
-		file_common_proto_restaurants_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_proto_restaurants_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MenuItem); i {
 			case 0:
 				return &v.state
@@ -295,18 +310,18 @@ func file_common_proto_restaurants_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_common_proto_restaurants_proto_rawDesc,
+			RawDescriptor: file_proto_restaurants_proto_rawDesc,
 			NumEnums:      0,
 			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_common_proto_restaurants_proto_goTypes,
-		DependencyIndexes: file_common_proto_restaurants_proto_depIdxs,
-		MessageInfos:      file_common_proto_restaurants_proto_msgTypes,
+		GoTypes:           file_proto_restaurants_proto_goTypes,
+		DependencyIndexes: file_proto_restaurants_proto_depIdxs,
+		MessageInfos:      file_proto_restaurants_proto_msgTypes,
 	}.Build()
-	File_common_proto_restaurants_proto = out.File
-	file_common_proto_restaurants_proto_rawDesc = nil
-	file_common_proto_restaurants_proto_goTypes = nil
-	file_common_proto_restaurants_proto_depIdxs = nil
+	File_proto_restaurants_proto = out.File
+	file_proto_restaurants_proto_rawDesc = nil
+	file_proto_restaurants_proto_goTypes = nil
+	file_proto_restaurants_proto_depIdxs = nil
 }
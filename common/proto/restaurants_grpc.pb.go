@@ -2,7 +2,7 @@
 // versions:
 // - protoc-gen-go-grpc v1.2.0
 // - protoc             v5.29.3
-// source: common/proto/restaurants.proto
+// source: proto/restaurants.proto
 
 package proto
 
@@ -101,5 +101,5 @@ var RestaurantService_ServiceDesc = grpc.ServiceDesc{
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "common/proto/restaurants.proto",
+	Metadata: "proto/restaurants.proto",
 }
@@ -24,7 +24,7 @@ func SetupRoutes(deliveryStore *store.DeliveryStore, courierStore *store.Courier
 		fmt.Fprint(w, "Couriers service is up and running!")
 	})
 
-	couriersHandler := handlers.NewCourierHandler(courierStore, producer, ordersClient)
+	couriersHandler := handlers.NewCourierHandler(courierStore, deliveryStore, producer, ordersClient)
 
 	r.Route("/couriers", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
@@ -36,6 +36,8 @@ func SetupRoutes(deliveryStore *store.DeliveryStore, courierStore *store.Courier
 			// r.Delete("/{id}", couriersHandler.DeleteCourier)
 		})
 
+		r.With(middleware.Authorize(auth.RoleCourier)).Get("/me/deliveries", couriersHandler.GetMyDeliveries)
+
 	})
 
 	r.Route("/orders", func(r chi.Router) {
@@ -45,6 +47,9 @@ func SetupRoutes(deliveryStore *store.DeliveryStore, courierStore *store.Courier
 			r.Post("/{id}/picked_up", couriersHandler.PickUpOrder)
 			r.Post("/{id}/delivered", couriersHandler.DeliverOrder)
 		})
+
+		// only the assigned courier sees where the order goes
+		r.With(middleware.AuthorizeOwnerOrRoles(deliveryStore.GetPerformerID, auth.RoleAdmin)).Get("/{id}", couriersHandler.GetDelivery)
 	})
 
 	return r
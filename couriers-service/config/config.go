@@ -29,6 +29,7 @@ type Config struct {
 			OrderPaid      string `mapstructure:"order_paid"`
 			OrderPickedUp  string `mapstructure:"order_picked_up"`
 			OrderDelivered string `mapstructure:"order_delivered"`
+			OrderCancelled string `mapstructure:"order_cancelled"`
 
 			CourierRequested    string `mapstructure:"courier_requested"`
 			CourierAssigned     string `mapstructure:"courier_assigned"`
@@ -16,9 +16,10 @@ import (
 )
 
 type CourierHandler struct {
-	store        *store.CourierStore
-	producer     *messaging.Producer
-	ordersClient pb.OrderServiceClient
+	store         *store.CourierStore
+	deliveryStore *store.DeliveryStore
+	producer      *messaging.Producer
+	ordersClient  pb.OrderServiceClient
 }
 
 type CourierUpdateRequest struct {
@@ -26,11 +27,12 @@ type CourierUpdateRequest struct {
 	Status string `json:"status" validate:"required"`
 }
 
-func NewCourierHandler(s *store.CourierStore, p *messaging.Producer, ordersClient pb.OrderServiceClient) *CourierHandler {
+func NewCourierHandler(s *store.CourierStore, ds *store.DeliveryStore, p *messaging.Producer, ordersClient pb.OrderServiceClient) *CourierHandler {
 	return &CourierHandler{
-		store:        s,
-		producer:     p,
-		ordersClient: ordersClient,
+		store:         s,
+		deliveryStore: ds,
+		producer:      p,
+		ordersClient:  ordersClient,
 	}
 }
 
@@ -106,6 +108,42 @@ func (h *CourierHandler) UpdateCourier(w http.ResponseWriter, r *http.Request) {
 // 	json.NewEncoder(w).Encode("Courier deleted!")
 // }
 
+// GetMyDeliveries lists the orders assigned to the calling courier with
+// their delivery addresses.
+func (h *CourierHandler) GetMyDeliveries(w http.ResponseWriter, r *http.Request) {
+	courierID := r.Header.Get("X-User-Id")
+
+	deliveries, err := h.deliveryStore.GetByCourierID(r.Context(), courierID)
+	if err != nil {
+		slog.Error("failed to get courier deliveries", "courier_id", courierID, "error", err)
+		http.Error(w, "Error getting deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+func (h *CourierHandler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+
+	delivery, err := h.deliveryStore.GetByOrderID(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Delivery not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to get delivery", "order_id", orderID, "error", err)
+		http.Error(w, "Error getting delivery", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(delivery)
+}
+
 func (h *CourierHandler) PickUpOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "id")
 	courierID := r.Header.Get("X-User-Id")
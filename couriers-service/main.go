@@ -49,7 +49,7 @@ func main() {
 		Handler: router,
 	}
 
-	messaging.StartConsumers(ctx, courierStore, deliveryStore, kafkaProducer)
+	messaging.StartConsumers(ctx, courierStore, deliveryStore, orderGRPCClient, kafkaProducer)
 
 	go func() {
 		slog.Info("starting couriers service", "port", config.Cfg.HTTP.Port)
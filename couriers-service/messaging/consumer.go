@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
 	"github.com/MatTwix/Food-Delivery-Agregator/common/auth"
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/couriers-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/couriers-service/models"
 	"github.com/MatTwix/Food-Delivery-Agregator/couriers-service/store"
@@ -18,7 +20,9 @@ import (
 )
 
 type CourierRequestedEvent struct {
-	OrderID string `json:"order_id"`
+	OrderID         string           `json:"order_id"`
+	RestaurantID    string           `json:"restaurant_id"`
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
 }
 
 type OrderPickedUpEvent struct {
@@ -33,8 +37,14 @@ type OrderDeliveredEvent struct {
 	UserID    string `json:"user_id"`
 }
 
+type OrderCancelledEvent struct {
+	OrderID   string `json:"order_id"`
+	CourierID string `json:"courier_id,omitempty"`
+}
+
 type CourierAssignedEvent struct {
-	CourierID string `json:"courier_id"`
+	CourierID       string           `json:"courier_id"`
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
 }
 
 type UsersRoleAssignedEvent struct {
@@ -44,15 +54,19 @@ type UsersRoleAssignedEvent struct {
 	Name     string `json:"name"`
 }
 
-func StartConsumers(ctx context.Context, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore, p *Producer) {
+func StartConsumers(ctx context.Context, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore, ordersClient pb.OrderServiceClient, p *Producer) {
 	go startTopicConsumer(ctx, CourierRequestedTopic, config.Cfg.Kafka.GroupIDs.Payments, func(ctx context.Context, msg kafka.Message) {
-		handleCourierRequested(ctx, msg, courierStore, deliveryStore, p)
+		handleCourierRequested(ctx, msg, courierStore, deliveryStore, ordersClient, p)
 	})
 
 	go startTopicConsumer(ctx, OrderDeliveredTopic, config.Cfg.Kafka.GroupIDs.Orders, func(ctx context.Context, msg kafka.Message) {
 		handleOrderDelivered(ctx, msg, courierStore)
 	})
 
+	go startTopicConsumer(ctx, OrderCancelledTopic, config.Cfg.Kafka.GroupIDs.Orders, func(ctx context.Context, msg kafka.Message) {
+		handleOrderCancelled(ctx, msg, courierStore, deliveryStore)
+	})
+
 	go startTopicConsumer(ctx, UsersRoleAssignedTopic, config.Cfg.Kafka.GroupIDs.Users, func(ctx context.Context, msg kafka.Message) {
 		handleUsersRoleAssigned(ctx, msg, courierStore)
 	})
@@ -105,7 +119,11 @@ func startTopicConsumer(ctx context.Context, topic, groupID string, handler func
 	}
 }
 
-func handleCourierRequested(ctx context.Context, msg kafka.Message, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore, p *Producer) {
+// handleCourierRequested assigns an available courier to the order. The
+// order status is checked once the courier is taken, and a courier taken for
+// an order cancelled in the meantime is released again: its order.cancelled
+// may have been handled before the delivery existed.
+func handleCourierRequested(ctx context.Context, msg kafka.Message, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore, ordersClient pb.OrderServiceClient, p *Producer) {
 	slog.Info("handling event", "event", CourierRequestedTopic)
 
 	var receivedEvent CourierRequestedEvent
@@ -127,8 +145,10 @@ func handleCourierRequested(ctx context.Context, msg kafka.Message, courierStore
 	}
 
 	delivery := models.Delivery{
-		CourierID: courier.ID,
-		OrderID:   receivedEvent.OrderID,
+		CourierID:       courier.ID,
+		OrderID:         receivedEvent.OrderID,
+		RestaurantID:    receivedEvent.RestaurantID,
+		DeliveryAddress: receivedEvent.DeliveryAddress,
 	}
 
 	if err := deliveryStore.Create(ctx, &delivery); err != nil {
@@ -141,8 +161,22 @@ func handleCourierRequested(ctx context.Context, msg kafka.Message, courierStore
 		return
 	}
 
+	resp, err := ordersClient.GetOrderStatus(ctx, &pb.GetOrderStatusRequest{
+		OrderId: receivedEvent.OrderID,
+	})
+	if err != nil {
+		slog.Warn("failed to check order status, assigning courier anyway", "order_id", receivedEvent.OrderID, "error", err)
+	} else if resp.Status == "cancelled" {
+		slog.Info("order was cancelled while searching for a courier", "order_id", receivedEvent.OrderID, "courier_id", courier.ID)
+		if _, err := releaseCourier(ctx, receivedEvent.OrderID, courierStore, deliveryStore); err != nil {
+			slog.Error("failed to release courier from cancelled order", "order_id", receivedEvent.OrderID, "courier_id", courier.ID, "error", err)
+		}
+		return
+	}
+
 	sendingEvent := CourierAssignedEvent{
-		CourierID: courier.ID,
+		CourierID:       courier.ID,
+		DeliveryAddress: receivedEvent.DeliveryAddress,
 	}
 
 	eventBody, err := json.Marshal(sendingEvent)
@@ -177,6 +211,39 @@ func handleOrderDelivered(ctx context.Context, msg kafka.Message, courierStore *
 	slog.Info("courier became available", "courier_id", event.CourierID)
 }
 
+func handleOrderCancelled(ctx context.Context, msg kafka.Message, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore) {
+	slog.Info("handling event", "event", OrderCancelledTopic)
+
+	var event OrderCancelledEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	courierID, err := releaseCourier(ctx, event.OrderID, courierStore, deliveryStore)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			slog.Info("no courier was assigned to cancelled order", "order_id", event.OrderID)
+		} else {
+			slog.Error("failed to release courier from cancelled order", "order_id", event.OrderID, "error", err)
+		}
+		return
+	}
+
+	slog.Info("courier released from cancelled order", "order_id", event.OrderID, "courier_id", courierID)
+}
+
+// releaseCourier drops the delivery of the order and makes its courier
+// available again. It returns pgx.ErrNoRows if no courier was assigned.
+func releaseCourier(ctx context.Context, orderID string, courierStore *store.CourierStore, deliveryStore *store.DeliveryStore) (string, error) {
+	courierID, err := deliveryStore.Delete(ctx, orderID)
+	if err != nil {
+		return "", err
+	}
+
+	return courierID, courierStore.UpdateStatus(ctx, courierID, "available")
+}
+
 func handleUsersRoleAssigned(ctx context.Context, msg kafka.Message, courierStore *store.CourierStore) {
 	slog.Info("handling event", "event", UsersRoleAssignedTopic)
 
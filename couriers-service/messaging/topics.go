@@ -15,6 +15,7 @@ var (
 	OrderPaidTopic      string
 	OrderPickedUpTopic  string
 	OrderDeliveredTopic string
+	OrderCancelledTopic string
 
 	CourierRequestedTopic    string
 	CourierAssignedTopic     string
@@ -29,6 +30,7 @@ func InitTopicsNames() {
 	OrderPaidTopic = config.Cfg.Kafka.Topics.OrderPaid
 	OrderPickedUpTopic = config.Cfg.Kafka.Topics.OrderPickedUp
 	OrderDeliveredTopic = config.Cfg.Kafka.Topics.OrderDelivered
+	OrderCancelledTopic = config.Cfg.Kafka.Topics.OrderCancelled
 
 	CourierRequestedTopic = config.Cfg.Kafka.Topics.CourierRequested
 	CourierAssignedTopic = config.Cfg.Kafka.Topics.CourierAssigned
@@ -40,6 +42,7 @@ func InitTopicsNames() {
 		OrderPaidTopic,
 		OrderPickedUpTopic,
 		OrderDeliveredTopic,
+		OrderCancelledTopic,
 
 		CourierRequestedTopic,
 		CourierAssignedTopic,
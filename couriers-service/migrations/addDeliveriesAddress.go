@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddDeliveriesAddress adds the restaurant and the delivery address the courier
+// gets with the order.
+func AddDeliveriesAddress(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS restaurant_id UUID;
+		ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS delivery_address JSONB;
+	`)
+	if err != nil {
+		slog.Error("failed to add deliveries address columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -5,4 +5,5 @@ import "github.com/jackc/pgx/v5/pgxpool"
 func Migrate(db *pgxpool.Pool) {
 	CreateCouriersTable(db)
 	CreateDeliveriesTable(db)
+	AddDeliveriesAddress(db)
 }
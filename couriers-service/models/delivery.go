@@ -1,9 +1,16 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+)
 
 type Delivery struct {
-	OrderID    string    `json:"order_id"`
-	CourierID  string    `json:"courier_id"`
-	AssignedAt time.Time `json:"assigned_at"`
+	OrderID      string `json:"order_id"`
+	CourierID    string `json:"courier_id"`
+	RestaurantID string `json:"restaurant_id,omitempty"`
+	// DeliveryAddress is missing for orders placed before addresses were collected.
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
+	AssignedAt      time.Time        `json:"assigned_at"`
 }
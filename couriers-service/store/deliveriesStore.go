@@ -29,18 +29,86 @@ func (s *DeliveryStore) GetPerformerID(ctx context.Context, orderID string) (str
 	return courierID, err
 }
 
+// GetByOrderID returns the delivery of the order or pgx.ErrNoRows if no
+// courier is assigned to it.
+func (s *DeliveryStore) GetByOrderID(ctx context.Context, orderID string) (models.Delivery, error) {
+	query := `
+		SELECT order_id, courier_id, COALESCE(restaurant_id::text, ''), delivery_address, assigned_at
+		FROM deliveries
+		WHERE order_id = $1
+	`
+
+	var delivery models.Delivery
+
+	err := s.db.QueryRow(ctx, query, orderID).Scan(
+		&delivery.OrderID,
+		&delivery.CourierID,
+		&delivery.RestaurantID,
+		&delivery.DeliveryAddress,
+		&delivery.AssignedAt,
+	)
+
+	return delivery, err
+}
+
+// GetByCourierID returns the deliveries currently assigned to the courier,
+// oldest first.
+func (s *DeliveryStore) GetByCourierID(ctx context.Context, courierID string) ([]models.Delivery, error) {
+	query := `
+		SELECT order_id, courier_id, COALESCE(restaurant_id::text, ''), delivery_address, assigned_at
+		FROM deliveries
+		WHERE courier_id = $1
+		ORDER BY assigned_at ASC
+	`
+
+	rows, err := s.db.Query(ctx, query, courierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []models.Delivery{}
+	for rows.Next() {
+		var delivery models.Delivery
+		if err := rows.Scan(
+			&delivery.OrderID,
+			&delivery.CourierID,
+			&delivery.RestaurantID,
+			&delivery.DeliveryAddress,
+			&delivery.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
 func (s *DeliveryStore) Create(ctx context.Context, delivery *models.Delivery) error {
 	query := `
 		INSERT INTO 
 		deliveries
-		(order_id, courier_id)
+		(order_id, courier_id, restaurant_id, delivery_address)
 		VALUES
-		($1, $2)
+		($1, $2, NULLIF($3, '')::uuid, $4)
 		RETURNING assigned_at
 	`
 
-	err := s.db.QueryRow(ctx, query, delivery.OrderID, delivery.CourierID).
+	err := s.db.QueryRow(ctx, query, delivery.OrderID, delivery.CourierID, delivery.RestaurantID, delivery.DeliveryAddress).
 		Scan(&delivery.AssignedAt)
 
 	return err
 }
+
+func (s *DeliveryStore) Delete(ctx context.Context, orderID string) (courierID string, err error) {
+	query := `
+		DELETE FROM deliveries
+		WHERE order_id = $1
+		RETURNING courier_id
+	`
+
+	err = s.db.QueryRow(ctx, query, orderID).Scan(&courierID)
+
+	return courierID, err
+}
@@ -18,10 +18,15 @@ type Config struct {
 			PaymentSucceeded string `mapstructure:"payment_succeeded"`
 			PaymentFailed    string `mapstructure:"payment_failed"`
 
+			RefundSucceeded string `mapstructure:"refund_succeeded"`
+			RefundFailed    string `mapstructure:"refund_failed"`
+
 			OrderCreated   string `mapstructure:"order_created"`
 			OrderUpdated   string `mapstructure:"order_updated"`
 			OrderPickedUp  string `mapstructure:"order_picked_up"`
 			OrderDelivered string `mapstructure:"order_delivered"`
+			OrderCancelled string `mapstructure:"order_cancelled"`
+			OrderExpired   string `mapstructure:"order_expired"`
 
 			NotificationCreated string `mapstructure:"notification_created"`
 		} `mapstructure:"topics"`
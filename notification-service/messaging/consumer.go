@@ -100,10 +100,18 @@ func handleNotificaion(ctx context.Context, msg kafka.Message, p *Producer) {
 		notificationMessage = "Payment was successfull."
 	case PaymentFailedTopic:
 		notificationMessage = "Payment failed."
+	case RefundSucceededTopic:
+		notificationMessage = "Refund has been issued."
+	case RefundFailedTopic:
+		notificationMessage = "Refund failed, our support team will contact you."
 	case OrderPickedUpTopic:
 		notificationMessage = "Order picked up by courier."
 	case OrderDeliveredTopic:
 		notificationMessage = "Order delivered."
+	case OrderCancelledTopic:
+		notificationMessage = "Order has been cancelled."
+	case OrderExpiredTopic:
+		notificationMessage = "Order has expired because it was not paid in time."
 	default:
 		notificationMessage = "An unknown event occured."
 	}
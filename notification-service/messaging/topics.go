@@ -15,10 +15,15 @@ var (
 	PaymentSucceededTopic string
 	PaymentFailedTopic    string
 
+	RefundSucceededTopic string
+	RefundFailedTopic    string
+
 	OrderCreatedTopic   string
 	OrderUpdatedTopic   string
 	OrderPickedUpTopic  string
 	OrderDeliveredTopic string
+	OrderCancelledTopic string
+	OrderExpiredTopic   string
 
 	NotificationCreatedTopic string
 )
@@ -29,10 +34,15 @@ func InitTopicsNames() {
 	PaymentSucceededTopic = config.Cfg.Kafka.Topics.PaymentSucceeded
 	PaymentFailedTopic = config.Cfg.Kafka.Topics.PaymentFailed
 
+	RefundSucceededTopic = config.Cfg.Kafka.Topics.RefundSucceeded
+	RefundFailedTopic = config.Cfg.Kafka.Topics.RefundFailed
+
 	OrderCreatedTopic = config.Cfg.Kafka.Topics.OrderCreated
 	OrderUpdatedTopic = config.Cfg.Kafka.Topics.OrderUpdated
 	OrderPickedUpTopic = config.Cfg.Kafka.Topics.OrderPickedUp
 	OrderDeliveredTopic = config.Cfg.Kafka.Topics.OrderDelivered
+	OrderCancelledTopic = config.Cfg.Kafka.Topics.OrderCancelled
+	OrderExpiredTopic = config.Cfg.Kafka.Topics.OrderExpired
 
 	NotificationCreatedTopic = config.Cfg.Kafka.Topics.NotificationCreated
 
@@ -40,10 +50,15 @@ func InitTopicsNames() {
 		PaymentSucceededTopic,
 		PaymentFailedTopic,
 
+		RefundSucceededTopic,
+		RefundFailedTopic,
+
 		OrderCreatedTopic,
 		OrderUpdatedTopic,
 		OrderPickedUpTopic,
 		OrderDeliveredTopic,
+		OrderCancelledTopic,
+		OrderExpiredTopic,
 
 		NotificationCreatedTopic,
 	}
@@ -54,10 +69,15 @@ func GetTopics() []string {
 		PaymentSucceededTopic,
 		PaymentFailedTopic,
 
+		RefundSucceededTopic,
+		RefundFailedTopic,
+
 		OrderCreatedTopic,
 		OrderUpdatedTopic,
 		OrderPickedUpTopic,
 		OrderDeliveredTopic,
+		OrderCancelledTopic,
+		OrderExpiredTopic,
 	}
 }
 
@@ -2,9 +2,15 @@ package api
 
 import (
 	"context"
+	"errors"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OrderGRPCServer struct {
@@ -40,6 +46,72 @@ func (s *OrderGRPCServer) GetOrderStatus(ctx context.Context, req *pb.GetOrderSt
 	}, nil
 }
 
+func (s *OrderGRPCServer) GetOrderRestaurant(ctx context.Context, req *pb.GetOrderRestaurantRequest) (*pb.GetOrderRestaurantResponce, error) {
+	restaurantID, orderStatus, err := s.orderStore.GetRestaurantIDAndStatus(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, err
+	}
+
+	return &pb.GetOrderRestaurantResponce{
+		RestaurantId: restaurantID,
+		Status:       orderStatus,
+	}, nil
+}
+
+func (s *OrderGRPCServer) GetDueScheduledOrders(ctx context.Context, req *pb.GetDueScheduledOrdersRequest) (*pb.GetDueScheduledOrdersResponce, error) {
+	orders, err := s.orderStore.GetDueScheduled(ctx, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbOrders []*pb.ScheduledOrder
+	for _, order := range orders {
+		pbOrders = append(pbOrders, &pb.ScheduledOrder{
+			Id:        order.ID,
+			DeliverAt: order.DeliverAt.Unix(),
+		})
+	}
+
+	return &pb.GetDueScheduledOrdersResponce{Orders: pbOrders}, nil
+}
+
+func (s *OrderGRPCServer) GetExpiredUnpaidOrders(ctx context.Context, req *pb.GetExpiredUnpaidOrdersRequest) (*pb.GetExpiredUnpaidOrdersResponce, error) {
+	orders, err := s.orderStore.GetExpiredUnpaid(ctx, config.Cfg.UnpaidOrders.TTL, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbOrders []*pb.UnpaidOrder
+	for _, order := range orders {
+		pbOrders = append(pbOrders, &pb.UnpaidOrder{
+			Id:          order.ID,
+			Status:      order.Status,
+			UnpaidSince: order.UpdatedAt.Unix(),
+		})
+	}
+
+	return &pb.GetExpiredUnpaidOrdersResponce{Orders: pbOrders}, nil
+}
+
+func (s *OrderGRPCServer) GetOrderForPayment(ctx context.Context, req *pb.GetOrderForPaymentRequest) (*pb.GetOrderForPaymentResponce, error) {
+	order, err := s.orderStore.GetForPayment(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, err
+	}
+
+	return &pb.GetOrderForPaymentResponce{
+		UserId:     order.UserID,
+		TotalPrice: money.ToProto(order.TotalPrice),
+		Status:     order.Status,
+	}, nil
+}
+
 func (s *OrderGRPCServer) GetRetryOrders(ctx context.Context, req *pb.GetRetryOrdersRequest) (*pb.GetRetryOrdersResponce, error) {
 	orders, err := s.orderStore.GetForRetry(ctx, req.Status, req.NextRetryAtLte, req.Limit)
 	if err != nil {
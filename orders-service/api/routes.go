@@ -6,20 +6,23 @@ import (
 
 	"github.com/MatTwix/Food-Delivery-Agregator/common/auth"
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/handlers"
-	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/messaging"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/middleware"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
-func SetupRoutes(restaurantStore *store.RestaurantStore, orderStore *store.OrderStore, grpcClient pb.RestaurantServiceClient, kafkaProducer *messaging.Producer) *chi.Mux {
+func SetupRoutes(restaurantStore *store.RestaurantStore, orderStore *store.OrderStore, promotionStore *store.PromotionStore, refundStore *store.RefundStore, cartStore *store.CartStore, idempotencyStore *store.IdempotencyStore, grpcClient pb.RestaurantServiceClient) *chi.Mux {
 	r := chi.NewRouter()
 	r.Use(chiMiddleware.Logger)
 	r.Use(chiMiddleware.Recoverer)
 
-	orderHandler := handlers.NewOrderHandler(orderStore, restaurantStore, grpcClient, kafkaProducer)
+	orderHandler := handlers.NewOrderHandler(orderStore, restaurantStore, promotionStore, cartStore, grpcClient)
+	promotionHandler := handlers.NewPromotionHandler(promotionStore)
+	refundHandler := handlers.NewRefundHandler(refundStore)
+	cartHandler := handlers.NewCartHandler(cartStore, orderHandler)
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "Orders service is up and running!")
@@ -29,15 +32,47 @@ func SetupRoutes(restaurantStore *store.RestaurantStore, orderStore *store.Order
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.Authorize(auth.RoleManager, auth.RoleAdmin))
 			r.Get("/", orderHandler.GetAllOrders)
+			r.Get("/transitions/rejected", orderHandler.GetRejectedTransitions)
+			r.With(middleware.Authorize(auth.RoleAdmin)).Post("/{id}/refunds", refundHandler.CreateRefund)
 		})
 
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.AuthorizeOwnerOrRoles(orderStore.GetOwnerID, auth.RoleAdmin, auth.RoleManager))
 			r.Get("/{id}", orderHandler.GetOrderByID)
+			r.Get("/{id}/timeline", orderHandler.GetOrderTimeline)
+			r.Get("/{id}/refunds", refundHandler.GetRefunds)
 			r.Post("/{id}/pay", orderHandler.RequestPayment)
+			r.Post("/{id}/cancel", orderHandler.CancelOrder)
+			r.With(middleware.Idempotency(idempotencyStore, config.Cfg.Idempotency.TTL)).Post("/{id}/reorder", orderHandler.Reorder)
 		})
 
-		r.Post("/", orderHandler.CreateOrder)
+		r.Get("/me", orderHandler.GetMyOrders)
+		r.Post("/quote", orderHandler.QuoteOrder)
+		r.With(middleware.Idempotency(idempotencyStore, config.Cfg.Idempotency.TTL)).Post("/", orderHandler.CreateOrder)
+	})
+
+	r.Route("/cart", func(r chi.Router) {
+		r.Get("/", cartHandler.GetCarts)
+		r.Get("/{restaurantId}", cartHandler.GetCart)
+		r.Put("/{restaurantId}", cartHandler.ReplaceCart)
+		r.Delete("/{restaurantId}", cartHandler.DeleteCart)
+		r.Post("/{restaurantId}/items", cartHandler.AddCartItem)
+		r.Patch("/{restaurantId}/items/{itemId}", cartHandler.UpdateCartItem)
+		r.Delete("/{restaurantId}/items/{itemId}", cartHandler.RemoveCartItem)
+		r.With(middleware.Idempotency(idempotencyStore, config.Cfg.Idempotency.TTL)).Post("/{restaurantId}/checkout", cartHandler.Checkout)
+	})
+
+	r.Route("/promotions", func(r chi.Router) {
+		r.Use(middleware.Authorize(auth.RoleManager, auth.RoleAdmin))
+		r.Get("/", promotionHandler.GetPromotions)
+		r.Post("/", promotionHandler.CreatePromotion)
+		r.Post("/{id}/activate", promotionHandler.ActivatePromotion)
+		r.Post("/{id}/deactivate", promotionHandler.DeactivatePromotion)
+	})
+
+	r.Route("/restaurants/{id}", func(r chi.Router) {
+		r.Use(middleware.AuthorizeStaffOrRoles(restaurantStore.IsStaff, auth.RoleAdmin, auth.RoleManager))
+		r.Get("/orders", orderHandler.GetRestaurantOrders)
 	})
 
 	return r
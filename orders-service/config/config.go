@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -18,12 +19,53 @@ type Config struct {
 	DB struct {
 		Source string `mapstructure:"source"`
 	} `mapstructure:"db"`
+	Idempotency struct {
+		TTL             time.Duration `mapstructure:"ttl"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	} `mapstructure:"idempotency"`
+	Outbox struct {
+		Retention       time.Duration `mapstructure:"retention"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	} `mapstructure:"outbox"`
+	CourierDispatch struct {
+		LeadTime      time.Duration `mapstructure:"lead_time"`
+		RetryInterval time.Duration `mapstructure:"retry_interval"`
+	} `mapstructure:"courier_dispatch"`
+	ScheduledOrders struct {
+		LeadTime time.Duration `mapstructure:"lead_time"`
+		MaxAhead time.Duration `mapstructure:"max_ahead"`
+	} `mapstructure:"scheduled_orders"`
+	UnpaidOrders struct {
+		TTL time.Duration `mapstructure:"ttl"`
+	} `mapstructure:"unpaid_orders"`
+	// Pricing amounts are in minor units of the order currency, rates in
+	// basis points (100 = 1%).
+	Pricing struct {
+		DeliveryFee struct {
+			// Mode is "flat" or "distance".
+			Mode   string  `mapstructure:"mode"`
+			Base   int64   `mapstructure:"base"`
+			PerKm  int64   `mapstructure:"per_km"`
+			FreeKm float64 `mapstructure:"free_km"`
+		} `mapstructure:"delivery_fee"`
+		ServiceFee struct {
+			RateBps int64 `mapstructure:"rate_bps"`
+		} `mapstructure:"service_fee"`
+		SmallOrder struct {
+			Threshold int64 `mapstructure:"threshold"`
+			Surcharge int64 `mapstructure:"surcharge"`
+		} `mapstructure:"small_order"`
+		Tax struct {
+			RateBps int64 `mapstructure:"rate_bps"`
+		} `mapstructure:"tax"`
+	} `mapstructure:"pricing"`
 	Kafka struct {
 		Brokers  string `mapstructure:"brokers"`
 		GroupIDs struct {
 			Restaurants string `mapstructure:"restaurants"`
 			Payments    string `mapstructure:"payments"`
 			Couriers    string `mapstructure:"couriers"`
+			Scheduler   string `mapstructure:"scheduler"`
 		} `mapstructure:"group_ids"`
 		Topics struct {
 			RestaurantCreated string `mapstructure:"restaurant_created"`
@@ -34,11 +76,25 @@ type Config struct {
 			OrderPaid      string `mapstructure:"order_paid"`
 			OrderPickedUp  string `mapstructure:"order_picked_up"`
 			OrderDelivered string `mapstructure:"order_delivered"`
+			OrderCancelled string `mapstructure:"order_cancelled"`
+			OrderExpired   string `mapstructure:"order_expired"`
+
+			OrderAccepted       string `mapstructure:"order_accepted"`
+			OrderRejected       string `mapstructure:"order_rejected"`
+			OrderPreparing      string `mapstructure:"order_preparing"`
+			OrderReadyForPickup string `mapstructure:"order_ready_for_pickup"`
+
+			OrderReleaseRequested string `mapstructure:"order_release_requested"`
+			OrderExpiryRequested  string `mapstructure:"order_expiry_requested"`
 
 			PaymentSucceeded string `mapstructure:"payment_succeeded"`
 			PaymentFailed    string `mapstructure:"payment_failed"`
 			PaymentRequested string `mapstructure:"payment_requested"`
 
+			RefundRequested string `mapstructure:"refund_requested"`
+			RefundSucceeded string `mapstructure:"refund_succeeded"`
+			RefundFailed    string `mapstructure:"refund_failed"`
+
 			CourierRequested    string `mapstructure:"courier_requested"`
 			CourierAssigned     string `mapstructure:"courier_assigned"`
 			CourierSearchFailed string `mapstructure:"courier_search_failed"`
@@ -0,0 +1,394 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+type CartHandler struct {
+	store  *store.CartStore
+	orders *OrderHandler
+}
+
+type ReplaceCartRequest struct {
+	Items []OrderItemRequest `json:"items" validate:"dive"`
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity" validate:"required,gt=0"`
+}
+
+type CheckoutRequest struct {
+	DeliveryAddress *address.Address `json:"delivery_address"`
+	DeliverAt       *time.Time       `json:"deliver_at,omitempty"`
+	PromoCode       string           `json:"promo_code,omitempty" validate:"max=64"`
+}
+
+func NewCartHandler(s *store.CartStore, orders *OrderHandler) *CartHandler {
+	return &CartHandler{
+		store:  s,
+		orders: orders,
+	}
+}
+
+// cartParams reads the caller and the restaurant of the cart from the request.
+func cartParams(w http.ResponseWriter, r *http.Request) (userID, restaurantID string, ok bool) {
+	userID = r.Header.Get("X-User-Id")
+	if userID == "" {
+		http.Error(w, "User ID is missing", http.StatusBadRequest)
+		return "", "", false
+	}
+
+	restaurantID = chi.URLParam(r, "restaurantId")
+	if config.Validator.Var(restaurantID, "uuid") != nil {
+		http.Error(w, "Restaurant ID must be a UUID", http.StatusBadRequest)
+		return "", "", false
+	}
+
+	return userID, restaurantID, true
+}
+
+func writeCart(w http.ResponseWriter, cart models.Cart) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(cart)
+}
+
+func (h *CartHandler) GetCarts(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-Id")
+	if userID == "" {
+		http.Error(w, "User ID is missing", http.StatusBadRequest)
+		return
+	}
+
+	carts, err := h.store.GetAll(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Error getting carts", http.StatusInternalServerError)
+		slog.Error("failed to get carts", "user_id", userID, "error", err)
+		return
+	}
+
+	if carts == nil {
+		carts = []models.Cart{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(carts)
+}
+
+func (h *CartHandler) GetCart(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	cart, err := h.store.Get(r.Context(), userID, restaurantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Cart not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error getting cart", http.StatusInternalServerError)
+		slog.Error("failed to get cart", "user_id", userID, "restaurant_id", restaurantID, "error", err)
+		return
+	}
+
+	writeCart(w, cart)
+}
+
+// ReplaceCart sets all items of the cart, creating it if needed.
+func (h *CartHandler) ReplaceCart(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReplaceCartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.priceItems(r, restaurantID, req.Items)
+	if err != nil {
+		writeOrderError(w, err, "Error updating cart")
+		return
+	}
+
+	cart, err := h.store.Replace(r.Context(), userID, restaurantID, items)
+	if err != nil {
+		http.Error(w, "Error updating cart", http.StatusInternalServerError)
+		slog.Error("failed to replace cart", "user_id", userID, "restaurant_id", restaurantID, "error", err)
+		return
+	}
+
+	writeCart(w, cart)
+}
+
+// AddCartItem puts an item into the cart, adding to its quantity if it is
+// there already.
+func (h *CartHandler) AddCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req OrderItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.priceItems(r, restaurantID, []OrderItemRequest{req})
+	if err != nil {
+		writeOrderError(w, err, "Error updating cart")
+		return
+	}
+
+	cart, err := h.store.AddItem(r.Context(), userID, restaurantID, items[0])
+	if err != nil {
+		http.Error(w, "Error updating cart", http.StatusInternalServerError)
+		slog.Error("failed to add cart item", "user_id", userID, "restaurant_id", restaurantID, "error", err)
+		return
+	}
+
+	writeCart(w, cart)
+}
+
+func (h *CartHandler) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateCartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	itemID := chi.URLParam(r, "itemId")
+	if config.Validator.Var(itemID, "uuid") != nil {
+		http.Error(w, "Item ID must be a UUID", http.StatusBadRequest)
+		return
+	}
+
+	cart, err := h.store.SetItemQuantity(r.Context(), userID, restaurantID, itemID, req.Quantity)
+	h.writeItemChange(w, cart, err, itemID)
+}
+
+func (h *CartHandler) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	itemID := chi.URLParam(r, "itemId")
+	if config.Validator.Var(itemID, "uuid") != nil {
+		http.Error(w, "Item ID must be a UUID", http.StatusBadRequest)
+		return
+	}
+
+	cart, err := h.store.RemoveItem(r.Context(), userID, restaurantID, itemID)
+	h.writeItemChange(w, cart, err, itemID)
+}
+
+func (h *CartHandler) writeItemChange(w http.ResponseWriter, cart models.Cart, err error, itemID string) {
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Item not found in cart", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error updating cart", http.StatusInternalServerError)
+		slog.Error("failed to change cart item", "item_id", itemID, "error", err)
+		return
+	}
+
+	writeCart(w, cart)
+}
+
+func (h *CartHandler) DeleteCart(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.store.Delete(r.Context(), userID, restaurantID)
+	if err != nil {
+		http.Error(w, "Error deleting cart", http.StatusInternalServerError)
+		slog.Error("failed to delete cart", "user_id", userID, "restaurant_id", restaurantID, "error", err)
+		return
+	}
+	if !deleted {
+		http.Error(w, "Cart not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Cart deleted")
+}
+
+// Checkout places an order from the cart through the same path as
+// CreateOrder and removes the cart. If menu prices changed since the items
+// were added, the cart gets the new prices and checkout fails with 409 so
+// the user can review them.
+func (h *CartHandler) Checkout(w http.ResponseWriter, r *http.Request) {
+	userID, restaurantID, ok := cartParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req CheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cart, err := h.store.Get(r.Context(), userID, restaurantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Cart not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error getting cart", http.StatusInternalServerError)
+		slog.Error("failed to get cart", "user_id", userID, "restaurant_id", restaurantID, "error", err)
+		return
+	}
+
+	if len(cart.Items) == 0 {
+		http.Error(w, "Cart is empty", http.StatusUnprocessableEntity)
+		return
+	}
+
+	orderReq := CreateOrderRequest{
+		RestaurantID:    restaurantID,
+		DeliveryAddress: req.DeliveryAddress,
+		DeliverAt:       req.DeliverAt,
+		PromoCode:       req.PromoCode,
+	}
+	for _, item := range cart.Items {
+		orderReq.Items = append(orderReq.Items, OrderItemRequest{MenuItemID: item.MenuItemID, Quantity: item.Quantity, Options: item.Options})
+	}
+
+	current, err := h.priceItems(r, restaurantID, orderReq.Items)
+	if err != nil {
+		writeOrderError(w, err, "Error checking out cart")
+		return
+	}
+
+	changed := make(map[string]money.Money)
+	for i, item := range cart.Items {
+		if current[i].Price != item.Price {
+			changed[item.MenuItemID] = current[i].Price
+		}
+	}
+
+	if len(changed) > 0 {
+		if err := h.store.UpdatePrices(r.Context(), cart.ID, changed); err != nil {
+			http.Error(w, "Error checking out cart", http.StatusInternalServerError)
+			slog.Error("failed to update cart prices", "cart_id", cart.ID, "error", err)
+			return
+		}
+		http.Error(w, "Prices of some items in the cart have changed, review the cart and check out again", http.StatusConflict)
+		return
+	}
+
+	order, err := h.orders.placeOrder(r.Context(), userID, orderReq, &cart)
+	if err != nil {
+		writeOrderError(w, err, "Error creating order")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
+
+// priceItems checks that the items exist in the restaurant and returns them
+// with their current menu prices, merging repeated menu items with the same
+// options.
+func (h *CartHandler) priceItems(r *http.Request, restaurantID string, items []OrderItemRequest) ([]models.CartItem, error) {
+	if err := h.orders.checkRestaurant(r.Context(), restaurantID); err != nil {
+		return nil, err
+	}
+
+	priced := cartItems(items)
+	if len(priced) == 0 {
+		return priced, nil
+	}
+
+	var menuItemIDs []string
+	for _, item := range priced {
+		if !slices.Contains(menuItemIDs, item.MenuItemID) {
+			menuItemIDs = append(menuItemIDs, item.MenuItemID)
+		}
+	}
+
+	menuItems, err := h.orders.getMenuItems(r.Context(), restaurantID, menuItemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range priced {
+		priced[i].Price = money.FromProto(menuItems[priced[i].MenuItemID].Price)
+	}
+
+	return priced, nil
+}
+
+// cartItems turns requested items into cart items without prices, merging
+// repeated menu items with the same options. Options are sorted so that their
+// order does not matter.
+func cartItems(items []OrderItemRequest) []models.CartItem {
+	var merged []models.CartItem
+	indexByKey := make(map[string]int)
+	for _, item := range items {
+		options := slices.Sorted(slices.Values(item.Options))
+		key := item.MenuItemID + "\x00" + strings.Join(options, "\x00")
+
+		if i, ok := indexByKey[key]; ok {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		indexByKey[key] = len(merged)
+		merged = append(merged, models.CartItem{MenuItemID: item.MenuItemID, Options: options, Quantity: item.Quantity})
+	}
+
+	return merged
+}
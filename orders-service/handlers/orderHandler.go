@@ -2,45 +2,70 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/messaging"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
 )
 
 type CreateOrderRequest struct {
-	RestaurantID string `json:"restaurant_id" validate:"required"`
-	Items        []struct {
-		MenuItemID string `json:"menu_item_id" validate:"required"`
-		Quantity   int    `json:"quantity" validate:"required"`
-	} `json:"items"`
+	RestaurantID string             `json:"restaurant_id" validate:"required,uuid"`
+	Items        []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	// DeliveryAddress is required to place the order but not to quote it.
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
+	// DeliverAt schedules the order for later instead of delivering it now.
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+	PromoCode string     `json:"promo_code,omitempty" validate:"max=64"`
 }
 
 type OrderHandler struct {
 	store           *store.OrderStore
 	restaurantStore *store.RestaurantStore
+	promotionStore  *store.PromotionStore
+	cartStore       *store.CartStore
 	grpcClient      pb.RestaurantServiceClient
-	producer        *messaging.Producer
 }
 
-func NewOrderHandler(os *store.OrderStore, rs *store.RestaurantStore, grpc pb.RestaurantServiceClient, p *messaging.Producer) *OrderHandler {
+func NewOrderHandler(os *store.OrderStore, rs *store.RestaurantStore, ps *store.PromotionStore, cs *store.CartStore, grpc pb.RestaurantServiceClient) *OrderHandler {
 	return &OrderHandler{
 		store:           os,
 		restaurantStore: rs,
+		promotionStore:  ps,
+		cartStore:       cs,
 		grpcClient:      grpc,
-		producer:        p,
 	}
 }
 
+const (
+	defaultOrdersPageSize = 50
+	maxOrdersPageSize     = 100
+)
+
 func (h *OrderHandler) GetAllOrders(w http.ResponseWriter, r *http.Request) {
-	orders, err := h.store.GetAll(r.Context())
+	filter, err := parseOrderFilter(r)
 	if err != nil {
+		http.Error(w, "Invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.store.List(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Error getting orders", http.StatusInternalServerError)
 		slog.Error("failed to get orders", "error", err)
 		return
@@ -48,7 +73,117 @@ func (h *OrderHandler) GetAllOrders(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(orders)
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *OrderHandler) GetMyOrders(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-Id")
+	if userID == "" {
+		http.Error(w, "User ID is missing", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		http.Error(w, "Invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.UserID = userID
+
+	page, err := h.store.List(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error getting orders", http.StatusInternalServerError)
+		slog.Error("failed to get user orders", "user_id", userID, "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(page)
+}
+
+// GetRestaurantOrders lists the live orders of one restaurant for its staff.
+func (h *OrderHandler) GetRestaurantOrders(w http.ResponseWriter, r *http.Request) {
+	restaurantID := chi.URLParam(r, "id")
+
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		http.Error(w, "Invalid query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.RestaurantID = restaurantID
+	filter.Statuses = models.RestaurantLiveStatuses
+
+	page, err := h.store.List(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, store.ErrInvalidCursor) {
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Error getting orders", http.StatusInternalServerError)
+		slog.Error("failed to get restaurant orders", "restaurant_id", restaurantID, "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(page)
+}
+
+func parseOrderFilter(r *http.Request) (models.OrderFilter, error) {
+	query := r.URL.Query()
+
+	filter := models.OrderFilter{
+		Status:       query.Get("status"),
+		RestaurantID: query.Get("restaurant_id"),
+		UserID:       query.Get("user_id"),
+		CourierID:    query.Get("courier_id"),
+		Cursor:       query.Get("cursor"),
+		Limit:        defaultOrdersPageSize,
+	}
+
+	if filter.Status != "" && !models.IsOrderStatus(filter.Status) {
+		return filter, fmt.Errorf("unknown status %q", filter.Status)
+	}
+
+	for name, id := range map[string]string{
+		"restaurant_id": filter.RestaurantID,
+		"user_id":       filter.UserID,
+		"courier_id":    filter.CourierID,
+	} {
+		if id != "" && config.Validator.Var(id, "uuid") != nil {
+			return filter, fmt.Errorf("%s must be a UUID", name)
+		}
+	}
+
+	for name, target := range map[string]**time.Time{
+		"created_from": &filter.CreatedFrom,
+		"created_to":   &filter.CreatedTo,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%s must be an RFC 3339 timestamp", name)
+		}
+		*target = &t
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return filter, errors.New("limit must be a positive integer")
+		}
+		filter.Limit = min(n, maxOrdersPageSize)
+	}
+
+	return filter, nil
 }
 
 func (h *OrderHandler) GetOrderByID(w http.ResponseWriter, r *http.Request) {
@@ -84,117 +219,147 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: Check req.RestaurantID existanse
-
-	menuItemIDs := []string{}
-	for _, item := range req.Items {
-		menuItemIDs = append(menuItemIDs, item.MenuItemID)
-	}
-
-	grpcReq := &pb.GetMenuItemsRequest{
-		RestaurantId: req.RestaurantID,
-		MenuItemIds:  menuItemIDs,
-	}
-
-	grpcRes, err := h.grpcClient.GetMenuItems(r.Context(), grpcReq)
+	order, err := h.placeOrder(r.Context(), userID, req, nil)
 	if err != nil {
-		slog.Error("failed to call to restaurants-service via gRPC", "error", err)
-		http.Error(w, "Error getting menu items info", http.StatusInternalServerError)
+		writeOrderError(w, err, "Error creating order")
 		return
 	}
 
-	if len(grpcRes.MenuItems) != len(menuItemIDs) {
-		http.Error(w, "Some menu items could not be found", http.StatusBadRequest)
-		return
-	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(order)
+}
 
-	order := &models.Order{
-		RestaurantID: req.RestaurantID,
-		Status:       "pending",
-		UserID:       userID,
-	}
+// OrderQuote is the price an order would have if it was placed now.
+type OrderQuote struct {
+	RestaurantID string             `json:"restaurant_id"`
+	Items        []models.OrderItem `json:"items"`
+	models.Pricing
+}
 
-	totalPrice := 0.0
-	menuItemsMap := make(map[string]*pb.MenuItem)
-	for _, item := range grpcRes.MenuItems {
-		menuItemsMap[item.Id] = item
+// QuoteOrder prices a CreateOrder request without placing the order or
+// redeeming its promo code.
+func (h *OrderHandler) QuoteOrder(w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("X-User-Id")
+	if userID == "" {
+		http.Error(w, "User ID is missing", http.StatusBadRequest)
+		return
 	}
 
-	for _, reqItem := range req.Items {
-		menuItem, ok := menuItemsMap[reqItem.MenuItemID]
-		if !ok {
-			http.Error(w, fmt.Sprintf("Menu item %s not found after gRPC call", reqItem.MenuItemID), http.StatusInternalServerError)
-			return
-		}
-		order.Items = append(order.Items, models.OrderItem{
-			MenuItemID: reqItem.MenuItemID,
-			Quantity:   reqItem.Quantity,
-			Price:      menuItem.Price,
-		})
-		totalPrice += menuItem.Price * float64(reqItem.Quantity)
+	var req CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
 	}
-	order.TotalPrice = totalPrice
 
-	if err := h.store.Create(r.Context(), order); err != nil {
-		slog.Error("failed to create order", "error", err)
-		http.Error(w, "Error creating order", http.StatusInternalServerError)
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	orderEventBody, err := json.Marshal(order)
+	order, err := h.buildOrder(r.Context(), userID, req)
 	if err != nil {
-		slog.Error("failed to marshal order for Kafka event", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.OrderCreatedTopic, []byte(order.ID), orderEventBody)
+		writeOrderError(w, err, "Error quoting order")
+		return
 	}
 
-	paymentEvent := messaging.PaymentRequestedEvent{
-		OrderID:    order.ID,
-		UserID:     order.UserID,
-		TotalPrice: order.TotalPrice,
+	quote := OrderQuote{
+		RestaurantID: order.RestaurantID,
+		Items:        order.Items,
+		Pricing:      order.Pricing,
 	}
 
-	paymentEventBody, err := json.Marshal(paymentEvent)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(quote)
+}
+
+func (h *OrderHandler) GetOrderTimeline(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+
+	timeline, err := h.store.GetStatusHistory(r.Context(), orderID)
 	if err != nil {
-		slog.Error("failed to marshal event for Kafka", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.PaymentRequestedTopic, []byte(order.ID), paymentEventBody)
+		http.Error(w, "Error getting order timeline", http.StatusInternalServerError)
+		slog.Error("failed to get order status history", "order_id", orderID, "error", err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(order)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(timeline)
 }
 
+func (h *OrderHandler) GetRejectedTransitions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(h.store.RejectedTransitions())
+}
+
+type RequestPaymentRequest struct {
+	// CardToken is the card to charge as tokenized by the payment provider.
+	CardToken string `json:"card_token,omitempty" validate:"max=255"`
+}
+
+// RequestPayment asks for another payment attempt. The payer is always the
+// order owner, whoever calls the endpoint.
 func (h *OrderHandler) RequestPayment(w http.ResponseWriter, r *http.Request) {
 	orderID := chi.URLParam(r, "id")
-	userID := r.Header.Get("X-User-Id")
-	if userID == "" {
-		http.Error(w, "User ID is missing", http.StatusBadRequest)
+
+	var req RequestPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	totalPrice, err := h.store.GetTotalPrice(r.Context(), orderID)
-	if err != nil {
-		slog.Error("failed to get total price", "orderID", orderID, "error", err)
-		http.Error(w, "Error getting total price", http.StatusInternalServerError)
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	event := messaging.PaymentRequestedEvent{
-		OrderID:    orderID,
-		UserID:     userID,
-		TotalPrice: totalPrice,
+	order, err := h.store.RequestPayment(r.Context(), orderID, messaging.PaymentRequestedEvents(req.CardToken))
+	if err != nil {
+		if errors.Is(err, store.ErrOrderNotPayable) {
+			http.Error(w, "Order can no longer be paid", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to request payment", "order_id", orderID, "error", err)
+		http.Error(w, "Error requesting payment", http.StatusInternalServerError)
+		return
 	}
 
-	eventBody, err := json.Marshal(event)
+	slog.Info("payment requested", "order_id", orderID, "attempt", order.PaymentAttempts)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Payment requested successfully")
+}
+
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	userID := r.Header.Get("X-User-Id")
+
+	order, prevStatus, err := h.store.Cancel(r.Context(), orderID, models.StatusSourceAPI, models.UserActor(userID), messaging.OrderCancelledEvents)
 	if err != nil {
-		slog.Error("failed to marshal event for Kafka", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.PaymentRequestedTopic, []byte(orderID), eventBody)
+		if errors.Is(err, store.ErrInvalidStatusTransition) {
+			http.Error(w, "Order can no longer be cancelled", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to cancel order", "order_id", orderID, "error", err)
+		http.Error(w, "Error cancelling order", http.StatusInternalServerError)
+		return
 	}
 
+	slog.Info("order cancelled", "order_id", order.ID, "previous_status", prevStatus)
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode("Payment requested successfully")
+	json.NewEncoder(w).Encode("Order cancelled successfully")
 }
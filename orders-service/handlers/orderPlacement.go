@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/messaging"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/pricing"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OrderItemRequest struct {
+	MenuItemID string `json:"menu_item_id" validate:"required,uuid"`
+	Quantity   int    `json:"quantity" validate:"required,gt=0"`
+	// Options are the choices made for the item, e.g. "no onions". They are
+	// kept with the order and do not change the price.
+	Options []string `json:"options,omitempty" validate:"max=20,dive,required,max=100"`
+}
+
+// orderError is an order placement rejection caused by the request itself.
+// It carries the HTTP status the client should get.
+type orderError struct {
+	status  int
+	message string
+}
+
+func (e *orderError) Error() string {
+	return e.message
+}
+
+func newOrderError(status int, format string, args ...any) *orderError {
+	return &orderError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// writeOrderError sends placement rejections with their own status and
+// everything else as 500 with the given message.
+func writeOrderError(w http.ResponseWriter, err error, message string) {
+	var orderErr *orderError
+	if errors.As(err, &orderErr) {
+		http.Error(w, orderErr.message, orderErr.status)
+		return
+	}
+
+	slog.Error("failed to place order", "error", err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// placeOrder prices the requested items of a single restaurant and stores the
+// new pending order together with its order.created and payment.requested events.
+// With DeliverAt set the order is stored as scheduled and charged on release.
+// A promo code is redeemed together with the order. An order placed from a
+// cart deletes it in the same transaction and fails with 409 if another
+// checkout of it got there first.
+func (h *OrderHandler) placeOrder(ctx context.Context, userID string, req CreateOrderRequest, cart *models.Cart) (*models.Order, error) {
+	if req.DeliveryAddress == nil {
+		return nil, newOrderError(http.StatusBadRequest, "Delivery address is required")
+	}
+
+	order, err := h.buildOrder(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart != nil {
+		err = h.store.CreateFromCart(ctx, order, *cart, models.StatusSourceAPI, models.UserActor(userID), messaging.OrderCreatedEvents)
+	} else {
+		err = h.store.Create(ctx, order, models.StatusSourceAPI, models.UserActor(userID), messaging.OrderCreatedEvents)
+	}
+	if err != nil {
+		if errors.Is(err, store.ErrPromotionExhausted) {
+			return nil, newOrderError(http.StatusUnprocessableEntity, "Promo code %s has reached its usage limit", order.Discount.Code)
+		}
+		if errors.Is(err, store.ErrCartChanged) {
+			return nil, newOrderError(http.StatusConflict, "Cart was changed or already checked out, review it and check out again")
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// buildOrder checks the request and prices it the way placeOrder would store
+// it, without storing anything.
+func (h *OrderHandler) buildOrder(ctx context.Context, userID string, req CreateOrderRequest) (*models.Order, error) {
+	restaurantID, items, deliverAt := req.RestaurantID, req.Items, req.DeliverAt
+
+	if err := h.checkRestaurant(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+
+	if deliverAt != nil {
+		if err := h.checkDeliverAt(ctx, restaurantID, *deliverAt); err != nil {
+			return nil, err
+		}
+	}
+
+	menuItemIDs := []string{}
+	for _, item := range items {
+		if !slices.Contains(menuItemIDs, item.MenuItemID) {
+			menuItemIDs = append(menuItemIDs, item.MenuItemID)
+		}
+	}
+
+	menuItemsMap, err := h.getMenuItems(ctx, restaurantID, menuItemIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.Order{
+		RestaurantID:    restaurantID,
+		Status:          models.OrderStatusPending,
+		UserID:          userID,
+		DeliveryAddress: req.DeliveryAddress,
+	}
+
+	if deliverAt != nil {
+		releaseAt := deliverAt.Add(-config.Cfg.ScheduledOrders.LeadTime)
+		order.Status = models.OrderStatusScheduled
+		order.DeliverAt = deliverAt
+		order.ReleaseAt = &releaseAt
+	}
+
+	var subtotal money.Money
+	for _, reqItem := range items {
+		menuItem := menuItemsMap[reqItem.MenuItemID]
+		price := money.FromProto(menuItem.Price)
+		if subtotal.Currency == "" {
+			subtotal.Currency = price.Currency
+		}
+
+		order.Items = append(order.Items, models.OrderItem{
+			MenuItemID:  reqItem.MenuItemID,
+			Name:        menuItem.Name,
+			Description: menuItem.Description,
+			Options:     reqItem.Options,
+			Quantity:    reqItem.Quantity,
+			Price:       price,
+		})
+
+		subtotal, err = subtotal.Add(price.Multiply(int64(reqItem.Quantity)))
+		if err != nil {
+			return nil, newOrderError(http.StatusUnprocessableEntity, "All menu items of an order must have the same currency")
+		}
+	}
+
+	var discount pricing.DiscountFunc
+	if req.PromoCode != "" {
+		discount = func(p models.Pricing) (*models.OrderDiscount, error) {
+			return h.promotionDiscount(ctx, userID, restaurantID, req.PromoCode, p)
+		}
+	}
+
+	distanceKm, err := h.deliveryDistanceKm(ctx, restaurantID, req.DeliveryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Pricing, err = pricing.Price(subtotal, distanceKm, discount)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// deliveryDistanceKm is the distance from the restaurant to the delivery
+// address, 0 if either has no coordinates.
+func (h *OrderHandler) deliveryDistanceKm(ctx context.Context, restaurantID string, deliveryAddress *address.Address) (float64, error) {
+	if deliveryAddress == nil || (deliveryAddress.Latitude == 0 && deliveryAddress.Longitude == 0) {
+		return 0, nil
+	}
+
+	latitude, longitude, err := h.restaurantStore.GetLocation(ctx, restaurantID)
+	if err != nil || latitude == nil || longitude == nil {
+		return 0, err
+	}
+
+	return address.DistanceKm(*latitude, *longitude, deliveryAddress.Latitude, deliveryAddress.Longitude), nil
+}
+
+// checkRestaurant fails with 404 if the restaurant is not in the cache kept
+// from restaurant events.
+func (h *OrderHandler) checkRestaurant(ctx context.Context, restaurantID string) error {
+	exists, err := h.restaurantStore.Exists(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return newOrderError(http.StatusNotFound, "Restaurant %s not found", restaurantID)
+	}
+
+	return nil
+}
+
+// getMenuItems loads the current menu items of the restaurant by id and
+// fails with 422 if any of them does not exist there.
+func (h *OrderHandler) getMenuItems(ctx context.Context, restaurantID string, menuItemIDs []string) (map[string]*pb.MenuItem, error) {
+	grpcReq := &pb.GetMenuItemsRequest{
+		RestaurantId: restaurantID,
+		MenuItemIds:  menuItemIDs,
+	}
+
+	grpcRes, err := h.grpcClient.GetMenuItems(ctx, grpcReq)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, newOrderError(http.StatusBadRequest, "Invalid menu items request: %s", status.Convert(err).Message())
+		}
+		return nil, fmt.Errorf("failed to get menu items from restaurants-service: %w", err)
+	}
+
+	menuItemsMap := make(map[string]*pb.MenuItem)
+	for _, item := range grpcRes.MenuItems {
+		menuItemsMap[item.Id] = item
+	}
+
+	var missingIDs []string
+	for _, id := range menuItemIDs {
+		if _, ok := menuItemsMap[id]; !ok {
+			missingIDs = append(missingIDs, id)
+		}
+	}
+	if len(missingIDs) > 0 {
+		return nil, newOrderError(http.StatusUnprocessableEntity, "Menu items not found in restaurant %s: %s", restaurantID, strings.Join(missingIDs, ", "))
+	}
+
+	return menuItemsMap, nil
+}
+
+// promotionDiscount checks the promo code against an order priced without it.
+// Usage limits are checked here for a clear error and enforced again when
+// the order is stored.
+func (h *OrderHandler) promotionDiscount(ctx context.Context, userID, restaurantID, code string, p models.Pricing) (*models.OrderDiscount, error) {
+	promotion, err := h.promotionStore.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newOrderError(http.StatusUnprocessableEntity, "Promo code %s not found", store.NormalizeCode(code))
+		}
+		return nil, err
+	}
+
+	amount, err := promotion.Discount(p.Subtotal, p.DeliveryFee, restaurantID, time.Now())
+	if err != nil {
+		var promotionErr *models.PromotionError
+		if errors.As(err, &promotionErr) {
+			return nil, newOrderError(http.StatusUnprocessableEntity, "%s", promotionErr.Reason)
+		}
+		return nil, err
+	}
+
+	if promotion.MaxRedemptionsPerUser != nil {
+		used, err := h.promotionStore.CountUserRedemptions(ctx, promotion.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= *promotion.MaxRedemptionsPerUser {
+			return nil, newOrderError(http.StatusUnprocessableEntity, "Promo code %s has already been used the maximum number of times", promotion.Code)
+		}
+	}
+
+	return &models.OrderDiscount{
+		PromotionID: promotion.ID,
+		Code:        promotion.Code,
+		Type:        promotion.Type,
+		Amount:      amount,
+	}, nil
+}
+
+// checkDeliverAt rejects delivery times too close or too far ahead and times
+// the restaurant is closed at.
+func (h *OrderHandler) checkDeliverAt(ctx context.Context, restaurantID string, deliverAt time.Time) error {
+	now := time.Now()
+	leadTime := config.Cfg.ScheduledOrders.LeadTime
+	maxAhead := config.Cfg.ScheduledOrders.MaxAhead
+
+	if deliverAt.Before(now.Add(leadTime)) {
+		return newOrderError(http.StatusUnprocessableEntity, "deliver_at must be at least %s ahead", leadTime)
+	}
+	if deliverAt.After(now.Add(maxAhead)) {
+		return newOrderError(http.StatusUnprocessableEntity, "deliver_at must be at most %s ahead", maxAhead)
+	}
+
+	timezone, openingHours, err := h.restaurantStore.GetOpeningHours(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("failed to load restaurant timezone %q: %w", timezone, err)
+	}
+
+	if !openingHours.IsOpenAt(deliverAt, loc) {
+		return newOrderError(http.StatusUnprocessableEntity, "Restaurant is closed at %s", deliverAt.In(loc).Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+type PromotionHandler struct {
+	store *store.PromotionStore
+}
+
+type promotionInput struct {
+	Code                  string       `json:"code" validate:"required,alphanum,max=64"`
+	Type                  string       `json:"type" validate:"required,oneof=percentage fixed_amount free_delivery"`
+	PercentOff            int          `json:"percent_off" validate:"gte=0,lte=100"`
+	AmountOff             *money.Money `json:"amount_off"`
+	MinOrderValue         *money.Money `json:"min_order_value"`
+	RestaurantID          *string      `json:"restaurant_id" validate:"omitempty,uuid"`
+	StartsAt              *time.Time   `json:"starts_at"`
+	EndsAt                *time.Time   `json:"ends_at"`
+	MaxRedemptions        *int         `json:"max_redemptions" validate:"omitempty,gt=0"`
+	MaxRedemptionsPerUser *int         `json:"max_redemptions_per_user" validate:"omitempty,gt=0"`
+}
+
+// validate checks what the struct tags cannot.
+func (input *promotionInput) validate() error {
+	if err := config.Validator.Struct(input); err != nil {
+		return err
+	}
+
+	switch input.Type {
+	case models.PromotionTypePercentage:
+		if input.PercentOff == 0 {
+			return errors.New("percent_off is required for percentage promotions")
+		}
+	case models.PromotionTypeFixedAmount:
+		if input.AmountOff == nil || input.AmountOff.IsZero() {
+			return errors.New("amount_off is required for fixed_amount promotions")
+		}
+	}
+	if input.Type != models.PromotionTypePercentage && input.PercentOff != 0 {
+		return errors.New("percent_off is only allowed for percentage promotions")
+	}
+	if input.Type != models.PromotionTypeFixedAmount && input.AmountOff != nil {
+		return errors.New("amount_off is only allowed for fixed_amount promotions")
+	}
+
+	if input.AmountOff != nil && input.MinOrderValue != nil && input.AmountOff.Currency != input.MinOrderValue.Currency {
+		return errors.New("amount_off and min_order_value must have the same currency")
+	}
+
+	if input.StartsAt != nil && input.EndsAt != nil && !input.EndsAt.After(*input.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+func NewPromotionHandler(s *store.PromotionStore) *PromotionHandler {
+	return &PromotionHandler{store: s}
+}
+
+func (h *PromotionHandler) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	promotions, err := h.store.GetAll(r.Context())
+	if err != nil {
+		http.Error(w, "Error getting promotions", http.StatusInternalServerError)
+		slog.Error("failed to get promotions", "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(promotions)
+}
+
+func (h *PromotionHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var input promotionInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := input.validate(); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	promotion := models.Promotion{
+		Code:                  input.Code,
+		Type:                  input.Type,
+		PercentOff:            input.PercentOff,
+		AmountOff:             input.AmountOff,
+		MinOrderValue:         input.MinOrderValue,
+		RestaurantID:          input.RestaurantID,
+		StartsAt:              input.StartsAt,
+		EndsAt:                input.EndsAt,
+		MaxRedemptions:        input.MaxRedemptions,
+		MaxRedemptionsPerUser: input.MaxRedemptionsPerUser,
+	}
+
+	if err := h.store.Create(r.Context(), &promotion); err != nil {
+		if errors.Is(err, store.ErrPromotionCodeTaken) {
+			http.Error(w, "Promo code already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Error creating promotion", http.StatusInternalServerError)
+		slog.Error("failed to create promotion", "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(promotion)
+}
+
+func (h *PromotionHandler) ActivatePromotion(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, true)
+}
+
+func (h *PromotionHandler) DeactivatePromotion(w http.ResponseWriter, r *http.Request) {
+	h.setActive(w, r, false)
+}
+
+func (h *PromotionHandler) setActive(w http.ResponseWriter, r *http.Request, active bool) {
+	id := chi.URLParam(r, "id")
+
+	promotion, err := h.store.SetActive(r.Context(), id, active)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Promotion not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error updating promotion", http.StatusInternalServerError)
+		slog.Error("failed to update promotion", "promotion_id", id, "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(promotion)
+}
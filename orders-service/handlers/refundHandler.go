@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/messaging"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+type RefundHandler struct {
+	store *store.RefundStore
+}
+
+type refundInput struct {
+	OrderItemID string `json:"order_item_id" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Reason      string `json:"reason" validate:"required,max=255"`
+}
+
+func NewRefundHandler(s *store.RefundStore) *RefundHandler {
+	return &RefundHandler{store: s}
+}
+
+func (h *RefundHandler) GetRefunds(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+
+	refunds, err := h.store.GetByOrderID(r.Context(), orderID)
+	if err != nil {
+		http.Error(w, "Error getting refunds", http.StatusInternalServerError)
+		slog.Error("failed to get refunds", "order_id", orderID, "error", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(refunds)
+}
+
+// CreateRefund refunds part of an order, such as an item missing from the
+// delivery. Cancelled and rejected orders are refunded in full on their own.
+func (h *RefundHandler) CreateRefund(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+	userID := r.Header.Get("X-User-Id")
+
+	var input refundInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	refund, err := h.store.RequestItemRefund(r.Context(), orderID, input.OrderItemID, input.Quantity, input.Reason, models.UserActor(userID), messaging.RefundRequestedEvents)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, store.ErrOrderItemNotFound) {
+			http.Error(w, "Order item not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, store.ErrOrderNotRefundable) {
+			http.Error(w, "Order cannot be refunded", http.StatusConflict)
+			return
+		}
+		if errors.Is(err, store.ErrRefundExceedsOrder) {
+			http.Error(w, "Refund exceeds what is left to refund", http.StatusUnprocessableEntity)
+			return
+		}
+		http.Error(w, "Error creating refund", http.StatusInternalServerError)
+		slog.Error("failed to create refund", "order_id", orderID, "error", err)
+		return
+	}
+
+	slog.Info("refund requested", "order_id", orderID, "refund_id", refund.ID, "amount", refund.Amount)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(refund)
+}
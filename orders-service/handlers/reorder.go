@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ReorderTargetOrder = "order"
+	ReorderTargetCart  = "cart"
+)
+
+type ReorderRequest struct {
+	// Target is "order" (default) to place the order right away or "cart"
+	// to put the items into the cart for review.
+	Target string `json:"target,omitempty" validate:"omitempty,oneof=order cart"`
+	// DeliveryAddress defaults to the address of the past order.
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
+}
+
+// ReorderPriceChange is an item of the past order whose menu price changed.
+type ReorderPriceChange struct {
+	MenuItemID string      `json:"menu_item_id"`
+	Name       string      `json:"name,omitempty"`
+	OldPrice   money.Money `json:"old_price"`
+	NewPrice   money.Money `json:"new_price"`
+}
+
+type ReorderResponse struct {
+	Order            *models.Order        `json:"order,omitempty"`
+	Cart             *models.Cart         `json:"cart,omitempty"`
+	UnavailableItems []models.OrderItem   `json:"unavailable_items"`
+	PriceChanges     []ReorderPriceChange `json:"price_changes"`
+}
+
+// Reorder places the items of a past order again for its owner, at current
+// menu prices and through the same path as CreateOrder. Items no longer on
+// the menu are left out; they and changed prices are reported.
+func (h *OrderHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "id")
+
+	var req ReorderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&req); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pastOrder, err := h.store.GetByID(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Error getting order by ID", http.StatusInternalServerError)
+		slog.Error("failed to get order for reorder", "order_id", orderID, "error", err)
+		return
+	}
+
+	if err := h.checkRestaurant(r.Context(), pastOrder.RestaurantID); err != nil {
+		writeOrderError(w, err, "Error reordering")
+		return
+	}
+
+	menuItemIDs := make([]string, 0, len(pastOrder.Items))
+	for _, item := range pastOrder.Items {
+		menuItemIDs = append(menuItemIDs, item.MenuItemID)
+	}
+
+	grpcRes, err := h.grpcClient.GetMenuItems(r.Context(), &pb.GetMenuItemsRequest{
+		RestaurantId: pastOrder.RestaurantID,
+		MenuItemIds:  menuItemIDs,
+	})
+	if err != nil {
+		http.Error(w, "Error getting menu items", http.StatusInternalServerError)
+		slog.Error("failed to get menu items from restaurants-service", "order_id", orderID, "error", err)
+		return
+	}
+
+	menuItemsMap := make(map[string]*pb.MenuItem)
+	for _, item := range grpcRes.MenuItems {
+		menuItemsMap[item.Id] = item
+	}
+
+	res := ReorderResponse{
+		UnavailableItems: []models.OrderItem{},
+		PriceChanges:     []ReorderPriceChange{},
+	}
+	orderReq := CreateOrderRequest{
+		RestaurantID:    pastOrder.RestaurantID,
+		DeliveryAddress: pastOrder.DeliveryAddress,
+	}
+	if req.DeliveryAddress != nil {
+		orderReq.DeliveryAddress = req.DeliveryAddress
+	}
+
+	for _, item := range pastOrder.Items {
+		menuItem, ok := menuItemsMap[item.MenuItemID]
+		if !ok {
+			res.UnavailableItems = append(res.UnavailableItems, item)
+			continue
+		}
+
+		// a menu item ordered with several sets of options is reported once
+		price := money.FromProto(menuItem.Price)
+		reported := slices.ContainsFunc(res.PriceChanges, func(c ReorderPriceChange) bool {
+			return c.MenuItemID == item.MenuItemID
+		})
+		if price != item.Price && !reported {
+			res.PriceChanges = append(res.PriceChanges, ReorderPriceChange{
+				MenuItemID: item.MenuItemID,
+				Name:       menuItem.Name,
+				OldPrice:   item.Price,
+				NewPrice:   price,
+			})
+		}
+
+		orderReq.Items = append(orderReq.Items, OrderItemRequest{
+			MenuItemID: item.MenuItemID,
+			Quantity:   item.Quantity,
+			Options:    item.Options,
+		})
+	}
+
+	if len(orderReq.Items) == 0 {
+		http.Error(w, "None of the items of the order are available anymore", http.StatusUnprocessableEntity)
+		return
+	}
+
+	if req.Target == ReorderTargetCart {
+		// cart items keep the options chosen on the past order
+		items := cartItems(orderReq.Items)
+		for i := range items {
+			items[i].Price = money.FromProto(menuItemsMap[items[i].MenuItemID].Price)
+		}
+
+		cart, err := h.cartStore.Replace(r.Context(), pastOrder.UserID, pastOrder.RestaurantID, items)
+		if err != nil {
+			http.Error(w, "Error reordering", http.StatusInternalServerError)
+			slog.Error("failed to fill cart for reorder", "order_id", orderID, "error", err)
+			return
+		}
+		res.Cart = &cart
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(res)
+		return
+	}
+
+	order, err := h.placeOrder(r.Context(), pastOrder.UserID, orderReq, nil)
+	if err != nil {
+		writeOrderError(w, err, "Error reordering")
+		return
+	}
+	res.Order = order
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(res)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/database"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/messaging"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/middleware"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
 )
 
@@ -38,6 +39,11 @@ func main() {
 
 	restaurantStore := store.NewRestaurantStore(db)
 	orderStore := store.NewOrderStore(db)
+	promotionStore := store.NewPromotionStore(db)
+	refundStore := store.NewRefundStore(db)
+	cartStore := store.NewCartStore(db)
+	outboxStore := store.NewOutboxStore(db)
+	idempotencyStore := store.NewIdempotencyStore(db)
 
 	kafkaProducer, err := messaging.NewProducer()
 	if err != nil {
@@ -50,13 +56,17 @@ func main() {
 
 	restaurantGRPCClient := clients.NewResraurantServiceClient()
 
-	router := api.SetupRoutes(restaurantStore, orderStore, restaurantGRPCClient, kafkaProducer)
+	router := api.SetupRoutes(restaurantStore, orderStore, promotionStore, refundStore, cartStore, idempotencyStore, restaurantGRPCClient)
 	httpServer := &http.Server{
 		Addr:    ":" + config.Cfg.HTTP.Port,
 		Handler: router,
 	}
 
-	messaging.StartConsumers(ctx, restaurantStore, orderStore, kafkaProducer)
+	messaging.StartConsumers(ctx, restaurantStore, orderStore, refundStore)
+	go messaging.StartOutboxRelay(ctx, outboxStore, kafkaProducer)
+	go messaging.StartOutboxCleanup(ctx, outboxStore, config.Cfg.Outbox.CleanupInterval, config.Cfg.Outbox.Retention)
+	go messaging.StartCourierDispatcher(ctx, orderStore)
+	go middleware.StartIdempotencyKeysCleanup(ctx, idempotencyStore, config.Cfg.Idempotency.CleanupInterval)
 
 	go func() {
 		lis, err := net.Listen("tcp", ":"+config.Cfg.GRPC.Port)
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
@@ -17,19 +19,91 @@ import (
 type OrderEvent struct {
 	OrderID string `json:"order_id"`
 }
+type CourierRequestedEvent struct {
+	OrderID         string           `json:"order_id"`
+	RestaurantID    string           `json:"restaurant_id"`
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
+}
+
 type CourierAssignedEvent struct {
 	CourierID string `json:"courier_id"`
 }
 
+type OrderPickedUpEvent struct {
+	CourierID string `json:"courier_id"`
+}
+
 type PaymentRequestedEvent struct {
-	OrderID    string  `json:"order_id"`
-	UserID     string  `json:"user_id"`
-	TotalPrice float64 `json:"total_price"`
+	OrderID    string      `json:"order_id"`
+	UserID     string      `json:"user_id"`
+	TotalPrice money.Money `json:"total_price"`
+	// Attempt numbers the payment requests of the order, starting at 1.
+	Attempt   int    `json:"attempt"`
+	CardToken string `json:"card_token,omitempty"`
+}
+
+type OrderCancelledEvent struct {
+	OrderID        string      `json:"order_id"`
+	UserID         string      `json:"user_id"`
+	CourierID      string      `json:"courier_id,omitempty"`
+	TotalPrice     money.Money `json:"total_price"`
+	PreviousStatus string      `json:"previous_status"`
+	RefundRequired bool        `json:"refund_required"`
+	Reason         string      `json:"reason,omitempty"`
+}
+
+type RefundRequestedEvent struct {
+	RefundID string      `json:"refund_id"`
+	OrderID  string      `json:"order_id"`
+	UserID   string      `json:"user_id"`
+	Amount   money.Money `json:"amount"`
+	Reason   string      `json:"reason"`
+}
+
+// RefundResultEvent is published by payments-service once the refund is
+// given back or has failed, in which case Reason says why.
+type RefundResultEvent struct {
+	RefundID string      `json:"refund_id"`
+	OrderID  string      `json:"order_id"`
+	UserID   string      `json:"user_id"`
+	Amount   money.Money `json:"amount"`
+	Reason   string      `json:"reason,omitempty"`
+}
+
+type OrderExpiredEvent struct {
+	OrderID        string `json:"order_id"`
+	UserID         string `json:"user_id"`
+	PreviousStatus string `json:"previous_status"`
+}
+
+// RestaurantOrderEvent is published by restaurants-service when the
+// restaurant moves one of its orders forward.
+type RestaurantOrderEvent struct {
+	OrderID      string `json:"order_id"`
+	RestaurantID string `json:"restaurant_id"`
+}
+
+type OrderAcceptedEvent struct {
+	OrderID          string    `json:"order_id"`
+	RestaurantID     string    `json:"restaurant_id"`
+	EstimatedReadyAt time.Time `json:"estimated_ready_at"`
+}
+
+type OrderRejectedEvent struct {
+	OrderID      string `json:"order_id"`
+	RestaurantID string `json:"restaurant_id"`
+	Reason       string `json:"reason"`
 }
 
+var (
+	paymentsServiceActor  = models.ServiceActor("payments-service")
+	couriersServiceActor  = models.ServiceActor("couriers-service")
+	schedulerServiceActor = models.ServiceActor("scheduler-service")
+)
+
 //TODO: refactor some consumers: make order delivery status changing be provided by single consumer
 
-func StartConsumers(ctx context.Context, restaurantStore *store.RestaurantStore, orderStore *store.OrderStore, p *Producer) {
+func StartConsumers(ctx context.Context, restaurantStore *store.RestaurantStore, orderStore *store.OrderStore, refundStore *store.RefundStore) {
 	go startTopicConsumer(ctx, RestaurantCreatedTopic, config.Cfg.Kafka.GroupIDs.Restaurants, func(ctx context.Context, msg kafka.Message) {
 		handleRestaurantCreated(ctx, msg, restaurantStore)
 	})
@@ -42,14 +116,46 @@ func StartConsumers(ctx context.Context, restaurantStore *store.RestaurantStore,
 		handleRestaurantDeleted(ctx, msg, restaurantStore)
 	})
 
+	go startTopicConsumer(ctx, OrderAcceptedTopic, config.Cfg.Kafka.GroupIDs.Restaurants, func(ctx context.Context, msg kafka.Message) {
+		handleOrderAccepted(ctx, msg, orderStore)
+	})
+
+	go startTopicConsumer(ctx, OrderRejectedTopic, config.Cfg.Kafka.GroupIDs.Restaurants, func(ctx context.Context, msg kafka.Message) {
+		handleOrderRejected(ctx, msg, orderStore)
+	})
+
+	go startTopicConsumer(ctx, OrderPreparingTopic, config.Cfg.Kafka.GroupIDs.Restaurants, func(ctx context.Context, msg kafka.Message) {
+		handleKitchenStatusChanged(ctx, msg, orderStore, models.OrderStatusPreparing)
+	})
+
+	go startTopicConsumer(ctx, OrderReadyForPickupTopic, config.Cfg.Kafka.GroupIDs.Restaurants, func(ctx context.Context, msg kafka.Message) {
+		handleKitchenStatusChanged(ctx, msg, orderStore, models.OrderStatusReadyForPickup)
+	})
+
+	go startTopicConsumer(ctx, OrderReleaseRequestedTopic, config.Cfg.Kafka.GroupIDs.Scheduler, func(ctx context.Context, msg kafka.Message) {
+		handleOrderReleaseRequested(ctx, msg, orderStore)
+	})
+
+	go startTopicConsumer(ctx, OrderExpiryRequestedTopic, config.Cfg.Kafka.GroupIDs.Scheduler, func(ctx context.Context, msg kafka.Message) {
+		handleOrderExpiryRequested(ctx, msg, orderStore)
+	})
+
 	go startTopicConsumer(ctx, PaymentSucceededTopic, config.Cfg.Kafka.GroupIDs.Payments, func(ctx context.Context, msg kafka.Message) {
-		handlePaymentSucceeded(ctx, msg, orderStore, p)
+		handlePaymentSucceeded(ctx, msg, orderStore)
 	})
 
 	go startTopicConsumer(ctx, PaymentFailedTopic, config.Cfg.Kafka.GroupIDs.Payments, func(ctx context.Context, msg kafka.Message) {
 		handlePaymentFailed(ctx, msg, orderStore)
 	})
 
+	go startTopicConsumer(ctx, RefundSucceededTopic, config.Cfg.Kafka.GroupIDs.Payments, func(ctx context.Context, msg kafka.Message) {
+		handleRefundSucceeded(ctx, msg, refundStore)
+	})
+
+	go startTopicConsumer(ctx, RefundFailedTopic, config.Cfg.Kafka.GroupIDs.Payments, func(ctx context.Context, msg kafka.Message) {
+		handleRefundFailed(ctx, msg, refundStore)
+	})
+
 	go startTopicConsumer(ctx, CourierAssignedTopic, config.Cfg.Kafka.GroupIDs.Couriers, func(ctx context.Context, msg kafka.Message) {
 		handleCourierAssigned(ctx, msg, orderStore)
 	})
@@ -170,26 +276,139 @@ func handleRestaurantDeleted(ctx context.Context, msg kafka.Message, store *stor
 	slog.Info("successfully deleted restaurant from the local database", "restaurant_id", restaurant.ID)
 }
 
-func handlePaymentSucceeded(ctx context.Context, msg kafka.Message, store *store.OrderStore, p *Producer) {
+// isRestaurantOrder reports whether the order was placed at the restaurant
+// that sent the event.
+func isRestaurantOrder(ctx context.Context, store *store.OrderStore, orderID, restaurantID string) bool {
+	orderRestaurantID, _, err := store.GetRestaurantIDAndStatus(ctx, orderID)
+	if err != nil {
+		slog.Error("failed to get order restaurant", "order_id", orderID, "error", err)
+		return false
+	}
+
+	if orderRestaurantID != restaurantID {
+		slog.Warn("restaurant event for an order of another restaurant", "order_id", orderID, "restaurant_id", restaurantID)
+		return false
+	}
+
+	return true
+}
+
+func handleOrderAccepted(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
 	orderID := string(msg.Key)
-	slog.Info("handeling event", "event", PaymentSucceededTopic, "order_id", orderID)
+	slog.Info("handling event", "event", OrderAcceptedTopic, "order_id", orderID)
 
-	if err := store.UpdateStatus(ctx, orderID, "paid"); err != nil {
-		slog.Error("failed to update order status to 'paid'", "order_id", orderID, "error", err)
+	var event OrderAcceptedEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if !isRestaurantOrder(ctx, store, orderID, event.RestaurantID) {
+		return
+	}
+
+	// the courier is requested so that it arrives about when the food is ready
+	courierDispatchAt := event.EstimatedReadyAt.Add(-config.Cfg.CourierDispatch.LeadTime)
+
+	if err := store.Accept(ctx, orderID, event.EstimatedReadyAt, courierDispatchAt, msg.Topic, models.RestaurantActor(event.RestaurantID)); err != nil {
+		slog.Error("failed to update order status to 'accepted'", "order_id", orderID, "error", err)
+		return
+	}
+
+	slog.Info("order status updated to 'accepted'", "order_id", orderID, "estimated_ready_at", event.EstimatedReadyAt, "courier_dispatch_at", courierDispatchAt)
+}
+
+func handleOrderRejected(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", OrderRejectedTopic, "order_id", orderID)
+
+	var event OrderRejectedEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if !isRestaurantOrder(ctx, store, orderID, event.RestaurantID) {
+		return
+	}
+
+	// a rejected order is announced as cancelled and its payment is refunded
+	_, _, err := store.Reject(ctx, orderID, msg.Topic, models.RestaurantActor(event.RestaurantID), func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error) {
+		return orderCancelledEvents(order, prevStatus, refund, event.Reason)
+	})
+	if err != nil {
+		slog.Error("failed to update order status to 'rejected'", "order_id", orderID, "error", err)
+		return
+	}
+
+	slog.Info("order status updated to 'rejected'", "order_id", orderID, "reason", event.Reason)
+}
+
+func handleKitchenStatusChanged(ctx context.Context, msg kafka.Message, store *store.OrderStore, status string) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", msg.Topic, "order_id", orderID)
+
+	var event RestaurantOrderEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
 		return
 	}
 
-	event := OrderEvent{
-		OrderID: orderID,
+	if !isRestaurantOrder(ctx, store, orderID, event.RestaurantID) {
+		return
+	}
+
+	if err := store.UpdateStatus(ctx, orderID, status, msg.Topic, models.RestaurantActor(event.RestaurantID)); err != nil {
+		slog.Error("failed to update order status", "order_id", orderID, "status", status, "error", err)
+		return
+	}
+
+	slog.Info("order status updated", "order_id", orderID, "status", status)
+}
+
+func handleOrderReleaseRequested(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", OrderReleaseRequestedTopic, "order_id", orderID)
+
+	if _, _, err := store.Release(ctx, orderID, msg.Topic, schedulerServiceActor, orderReleasedEvents); err != nil {
+		slog.Error("failed to update order status to 'pending'", "order_id", orderID, "error", err)
+		return
+	}
+
+	slog.Info("scheduled order released to payment", "order_id", orderID)
+}
+
+func handleOrderExpiryRequested(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", OrderExpiryRequestedTopic, "order_id", orderID)
+
+	_, prevStatus, err := store.Expire(ctx, orderID, config.Cfg.UnpaidOrders.TTL, msg.Topic, schedulerServiceActor, orderExpiredEvents)
+	if err != nil {
+		slog.Error("failed to update order status to 'expired'", "order_id", orderID, "error", err)
+		return
 	}
 
-	eventBody, err := json.Marshal(event)
+	slog.Info("unpaid order expired", "order_id", orderID, "previous_status", prevStatus)
+}
+
+func handlePaymentSucceeded(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
+	orderID := string(msg.Key)
+	slog.Info("handeling event", "event", PaymentSucceededTopic, "order_id", orderID)
+
+	events, err := orderPaidEvents(orderID)
 	if err != nil {
 		slog.Error("failed to marshal message for Kafka event", "error", err)
 		return
-	} else {
-		p.Produce(ctx, OrderPaidTopic, []byte(orderID), eventBody)
-		p.Produce(ctx, CourierRequestedTopic, []byte(orderID), eventBody)
+	}
+
+	refund, err := store.Pay(ctx, orderID, msg.Topic, paymentsServiceActor, events, RefundRequestedEvents)
+	if err != nil {
+		slog.Error("failed to update order status to 'paid'", "order_id", orderID, "error", err)
+		return
+	}
+	if refund != nil {
+		slog.Warn("order was paid after it was cancelled or expired, refund requested", "order_id", orderID, "refund_id", refund.ID)
+		return
 	}
 
 	slog.Info("order status updated to 'paid'", "order_id", orderID)
@@ -199,14 +418,48 @@ func handlePaymentFailed(ctx context.Context, msg kafka.Message, store *store.Or
 	orderID := string(msg.Key)
 	slog.Info("handeling event", "topic", PaymentFailedTopic, "order_id", orderID)
 
-	if err := store.UpdateStatus(ctx, orderID, "payment_failed"); err != nil {
+	if err := store.UpdateStatus(ctx, orderID, models.OrderStatusPaymentFailed, msg.Topic, paymentsServiceActor); err != nil {
 		slog.Error("failed to update order status to 'payment_failed'", "order_id", orderID, "error", err)
 		return
 	}
 
 	slog.Info("order status updated to 'payment_failed'", "order_id", orderID)
+}
+
+func handleRefundSucceeded(ctx context.Context, msg kafka.Message, store *store.RefundStore) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", RefundSucceededTopic, "order_id", orderID)
+
+	var event RefundResultEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if _, err := store.Complete(ctx, event.RefundID, msg.Topic, paymentsServiceActor); err != nil {
+		slog.Error("failed to complete refund", "order_id", orderID, "refund_id", event.RefundID, "error", err)
+		return
+	}
+
+	slog.Info("refund succeeded", "order_id", orderID, "refund_id", event.RefundID, "amount", event.Amount)
+}
+
+func handleRefundFailed(ctx context.Context, msg kafka.Message, store *store.RefundStore) {
+	orderID := string(msg.Key)
+	slog.Info("handling event", "event", RefundFailedTopic, "order_id", orderID)
+
+	var event RefundResultEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if _, err := store.Fail(ctx, event.RefundID, event.Reason); err != nil {
+		slog.Error("failed to mark refund as failed", "order_id", orderID, "refund_id", event.RefundID, "error", err)
+		return
+	}
 
-	// Make event for refund or notifier
+	slog.Warn("refund failed", "order_id", orderID, "refund_id", event.RefundID, "reason", event.Reason)
 }
 
 func handleCourierAssigned(ctx context.Context, msg kafka.Message, store *store.OrderStore) {
@@ -219,7 +472,7 @@ func handleCourierAssigned(ctx context.Context, msg kafka.Message, store *store.
 		return
 	}
 
-	if err := store.AssignCourier(ctx, orderID, event.CourierID); err != nil {
+	if err := store.AssignCourier(ctx, orderID, event.CourierID, msg.Topic, couriersServiceActor); err != nil {
 		slog.Error("failed to assign courier", "error", err)
 		return
 	}
@@ -238,7 +491,7 @@ func handleCourierSearchFailed(ctx context.Context, msg kafka.Message, store *st
 	}
 
 	if retriesExceeded {
-		if err := store.UpdateStatus(ctx, orderID, "retries_count_exceeded"); err != nil {
+		if err := store.UpdateStatus(ctx, orderID, models.OrderStatusRetriesCountExceeded, msg.Topic, couriersServiceActor); err != nil {
 			slog.Error("failed to update order status to 'retries_count_exceeded'", "order_id", orderID, "error", err)
 			return
 		}
@@ -247,7 +500,19 @@ func handleCourierSearchFailed(ctx context.Context, msg kafka.Message, store *st
 		return
 	}
 
-	if err := store.UpdateStatus(ctx, orderID, "no_couriers_available"); err != nil {
+	// accepted orders keep their kitchen status and are dispatched again later
+	rescheduled, err := store.RescheduleCourierDispatch(ctx, orderID, config.Cfg.CourierDispatch.RetryInterval)
+	if err != nil {
+		slog.Error("failed to reschedule courier dispatch", "order_id", orderID, "error", err)
+		return
+	}
+
+	if rescheduled {
+		slog.Info("courier dispatch rescheduled", "order_id", orderID, "retry_interval", config.Cfg.CourierDispatch.RetryInterval)
+		return
+	}
+
+	if err := store.UpdateStatus(ctx, orderID, models.OrderStatusNoCouriersAvailable, msg.Topic, couriersServiceActor); err != nil {
 		slog.Error("failed to update order status to 'no_available_couriers'", "order_id", orderID, "error", err)
 		return
 	}
@@ -259,7 +524,13 @@ func handleOrderPickedUp(ctx context.Context, msg kafka.Message, store *store.Or
 	orderID := string(msg.Key)
 	slog.Info("handling event", "topic", OrderPickedUpTopic, "order_id", orderID)
 
-	if err := store.UpdateStatus(ctx, orderID, "picked_up"); err != nil {
+	var event OrderPickedUpEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if err := store.PickUp(ctx, orderID, event.CourierID, msg.Topic, models.CourierActor(event.CourierID)); err != nil {
 		slog.Error("failed to update order status to 'picked_up'", "order_id", orderID, "error", err)
 		return
 	}
@@ -271,7 +542,13 @@ func handleOrderDelivered(ctx context.Context, msg kafka.Message, store *store.O
 	orderID := string(msg.Key)
 	slog.Info("handling event", "event", OrderDeliveredTopic, "order_id", orderID)
 
-	if err := store.UpdateStatus(ctx, orderID, "delivered"); err != nil {
+	var event OrderPickedUpEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal Kafka message", "error", err)
+		return
+	}
+
+	if err := store.UpdateStatus(ctx, orderID, models.OrderStatusDelivered, msg.Topic, models.CourierActor(event.CourierID)); err != nil {
 		slog.Error("failed to update order status to 'delivered'", "error", err)
 		return
 	}
@@ -0,0 +1,48 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+)
+
+const (
+	courierDispatchPollInterval = 5 * time.Second
+	courierDispatchBatchSize    = 100
+)
+
+// StartCourierDispatcher requests couriers for accepted orders once their
+// dispatch time, derived from the restaurant's estimated ready time, has
+// come. It runs until ctx is cancelled.
+func StartCourierDispatcher(ctx context.Context, orderStore *store.OrderStore) {
+	slog.Info("starting courier dispatcher", "poll_interval", courierDispatchPollInterval)
+
+	ticker := time.NewTicker(courierDispatchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping courier dispatcher due to context cancellation")
+			return
+		case <-ticker.C:
+			for {
+				dispatched, err := orderStore.DispatchCouriers(ctx, courierDispatchBatchSize, courierRequestedEvents)
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.Error("failed to dispatch couriers", "error", err)
+					}
+					break
+				}
+				if dispatched > 0 {
+					slog.Info("couriers requested for accepted orders", "amount", dispatched)
+				}
+				if dispatched < courierDispatchBatchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+)
+
+// Builders of the events orders-service writes to the outbox together with
+// the state change they announce.
+
+func OrderCreatedEvents(order *models.Order) ([]models.OutboxEvent, error) {
+	orderEvent, err := models.NewOutboxEvent(OrderCreatedTopic, order.ID, order)
+	if err != nil {
+		return nil, err
+	}
+
+	// scheduled orders are charged once scheduler-service releases them
+	if order.Status == models.OrderStatusScheduled {
+		return []models.OutboxEvent{orderEvent}, nil
+	}
+
+	paymentEvent, err := paymentRequestedEvent(*order, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{orderEvent, paymentEvent}, nil
+}
+
+func orderReleasedEvents(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error) {
+	paymentEvent, err := paymentRequestedEvent(order, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{paymentEvent}, nil
+}
+
+// PaymentRequestedEvents asks for another payment attempt of the order with
+// the card the customer chose.
+func PaymentRequestedEvents(cardToken string) func(order models.Order) ([]models.OutboxEvent, error) {
+	return func(order models.Order) ([]models.OutboxEvent, error) {
+		event, err := paymentRequestedEvent(order, cardToken)
+		if err != nil {
+			return nil, err
+		}
+
+		return []models.OutboxEvent{event}, nil
+	}
+}
+
+func paymentRequestedEvent(order models.Order, cardToken string) (models.OutboxEvent, error) {
+	return models.NewOutboxEvent(PaymentRequestedTopic, order.ID, PaymentRequestedEvent{
+		OrderID:    order.ID,
+		UserID:     order.UserID,
+		TotalPrice: order.TotalPrice,
+		Attempt:    order.PaymentAttempts,
+		CardToken:  cardToken,
+	})
+}
+
+func OrderCancelledEvents(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error) {
+	return orderCancelledEvents(order, prevStatus, refund, "")
+}
+
+// orderCancelledEvents announces the cancellation and, for orders that were
+// already charged, asks payments-service for the refund.
+func orderCancelledEvents(order models.Order, prevStatus string, refund *models.Refund, reason string) ([]models.OutboxEvent, error) {
+	event, err := models.NewOutboxEvent(OrderCancelledTopic, order.ID, OrderCancelledEvent{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		CourierID:      order.CourierID.String,
+		TotalPrice:     order.TotalPrice,
+		PreviousStatus: prevStatus,
+		RefundRequired: refund != nil,
+		Reason:         reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if refund == nil {
+		return []models.OutboxEvent{event}, nil
+	}
+
+	refundEvent, err := refundRequestedEvent(order, *refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{event, refundEvent}, nil
+}
+
+func RefundRequestedEvents(order models.Order, refund models.Refund) ([]models.OutboxEvent, error) {
+	event, err := refundRequestedEvent(order, refund)
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{event}, nil
+}
+
+func refundRequestedEvent(order models.Order, refund models.Refund) (models.OutboxEvent, error) {
+	return models.NewOutboxEvent(RefundRequestedTopic, order.ID, RefundRequestedEvent{
+		RefundID: refund.ID,
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Amount:   refund.Amount,
+		Reason:   refund.Reason,
+	})
+}
+
+func orderExpiredEvents(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error) {
+	event, err := models.NewOutboxEvent(OrderExpiredTopic, order.ID, OrderExpiredEvent{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		PreviousStatus: prevStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{event}, nil
+}
+
+func orderPaidEvents(orderID string) ([]models.OutboxEvent, error) {
+	event, err := models.NewOutboxEvent(OrderPaidTopic, orderID, OrderEvent{OrderID: orderID})
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{event}, nil
+}
+
+func courierRequestedEvents(order models.Order) ([]models.OutboxEvent, error) {
+	event, err := models.NewOutboxEvent(CourierRequestedTopic, order.ID, CourierRequestedEvent{
+		OrderID:         order.ID,
+		RestaurantID:    order.RestaurantID,
+		DeliveryAddress: order.DeliveryAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.OutboxEvent{event}, nil
+}
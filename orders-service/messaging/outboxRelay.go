@@ -0,0 +1,80 @@
+package messaging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	outboxPollInterval = 1 * time.Second
+	outboxBatchSize    = 100
+)
+
+// StartOutboxRelay publishes events from the outbox table to Kafka until ctx
+// is cancelled. Delivery is at-least-once: an event published right before a
+// failed commit is sent again on the next run.
+func StartOutboxRelay(ctx context.Context, outboxStore *store.OutboxStore, p *Producer) {
+	slog.Info("starting outbox relay", "poll_interval", outboxPollInterval)
+
+	ticker := time.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stopping outbox relay due to context cancellation")
+			return
+		case <-ticker.C:
+			// drain the backlog before waiting for the next tick; a batch holds
+			// one event per key, so it can be short while more are pending
+			for {
+				sent, err := outboxStore.Relay(ctx, outboxBatchSize, func(events []models.OutboxEvent) []error {
+					messages := make([]kafka.Message, len(events))
+					for i, event := range events {
+						messages[i] = kafka.Message{Topic: event.Topic, Key: []byte(event.Key), Value: event.Payload}
+					}
+					return p.ProduceBatch(ctx, messages)
+				})
+				if err != nil {
+					if ctx.Err() == nil {
+						slog.Error("failed to relay outbox events", "error", err)
+					}
+					break
+				}
+				if sent == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// StartOutboxCleanup deletes events sent more than retention ago every
+// interval until ctx is cancelled.
+func StartOutboxCleanup(ctx context.Context, outboxStore *store.OutboxStore, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := outboxStore.DeleteSent(ctx, retention)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("failed to delete sent outbox events", "error", err)
+				}
+				continue
+			}
+			if deleted > 0 {
+				slog.Info("sent outbox events deleted", "count", deleted)
+			}
+		}
+	}
+}
@@ -2,9 +2,11 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
 	"github.com/segmentio/kafka-go"
@@ -22,29 +24,42 @@ func NewProducer() (*Producer, error) {
 
 	brokers := strings.Split(config.Cfg.Kafka.Brokers, ",")
 
+	// events of one order share a key, so hashing it keeps them on one
+	// partition and in order when topics get more partitions
 	w := &kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{},
 		RequiredAcks: kafka.RequireAll,
+		// WriteMessages blocks until its batch is flushed, so the default of
+		// one second would cap every call at a second
+		BatchTimeout: 10 * time.Millisecond,
 	}
 
 	return &Producer{writer: w}, nil
 }
 
-func (p *Producer) Produce(ctx context.Context, topic string, key, value []byte) error {
-	err := p.writer.WriteMessages(ctx, kafka.Message{
-		Topic: topic,
-		Key:   key,
-		Value: value,
-	})
-	if err != nil {
-		slog.Error("failed to write message to Kafka topic", "topic", topic, "error", err)
-		return err
+// ProduceBatch writes all messages in one call and returns the error of every
+// message by index, or nil when all of them were written.
+func (p *Producer) ProduceBatch(ctx context.Context, messages []kafka.Message) []error {
+	err := p.writer.WriteMessages(ctx, messages...)
+	if err == nil {
+		slog.Info("messages sent", "count", len(messages))
+		return nil
 	}
 
-	slog.Info("message sent to topic", "topic", topic)
+	slog.Error("failed to write messages to Kafka", "count", len(messages), "error", err)
 
-	return nil
+	var writeErrors kafka.WriteErrors
+	if errors.As(err, &writeErrors) {
+		return writeErrors
+	}
+
+	errs := make([]error, len(messages))
+	for i := range errs {
+		errs[i] = err
+	}
+
+	return errs
 }
 
 func (p *Producer) Close() {
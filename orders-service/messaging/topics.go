@@ -20,11 +20,25 @@ var (
 	OrderPaidTopic      string
 	OrderPickedUpTopic  string
 	OrderDeliveredTopic string
+	OrderCancelledTopic string
+	OrderExpiredTopic   string
+
+	OrderAcceptedTopic       string
+	OrderRejectedTopic       string
+	OrderPreparingTopic      string
+	OrderReadyForPickupTopic string
+
+	OrderReleaseRequestedTopic string
+	OrderExpiryRequestedTopic  string
 
 	PaymentSucceededTopic string
 	PaymentFailedTopic    string
 	PaymentRequestedTopic string
 
+	RefundRequestedTopic string
+	RefundSucceededTopic string
+	RefundFailedTopic    string
+
 	CourierRequestedTopic    string
 	CourierAssignedTopic     string
 	CourierSearchFailedTopic string
@@ -41,11 +55,25 @@ func InitTopicsNames() {
 	OrderPaidTopic = config.Cfg.Kafka.Topics.OrderPaid
 	OrderPickedUpTopic = config.Cfg.Kafka.Topics.OrderPickedUp
 	OrderDeliveredTopic = config.Cfg.Kafka.Topics.OrderDelivered
+	OrderCancelledTopic = config.Cfg.Kafka.Topics.OrderCancelled
+	OrderExpiredTopic = config.Cfg.Kafka.Topics.OrderExpired
+
+	OrderAcceptedTopic = config.Cfg.Kafka.Topics.OrderAccepted
+	OrderRejectedTopic = config.Cfg.Kafka.Topics.OrderRejected
+	OrderPreparingTopic = config.Cfg.Kafka.Topics.OrderPreparing
+	OrderReadyForPickupTopic = config.Cfg.Kafka.Topics.OrderReadyForPickup
+
+	OrderReleaseRequestedTopic = config.Cfg.Kafka.Topics.OrderReleaseRequested
+	OrderExpiryRequestedTopic = config.Cfg.Kafka.Topics.OrderExpiryRequested
 
 	PaymentSucceededTopic = config.Cfg.Kafka.Topics.PaymentSucceeded
 	PaymentFailedTopic = config.Cfg.Kafka.Topics.PaymentFailed
 	PaymentRequestedTopic = config.Cfg.Kafka.Topics.PaymentRequested
 
+	RefundRequestedTopic = config.Cfg.Kafka.Topics.RefundRequested
+	RefundSucceededTopic = config.Cfg.Kafka.Topics.RefundSucceeded
+	RefundFailedTopic = config.Cfg.Kafka.Topics.RefundFailed
+
 	CourierRequestedTopic = config.Cfg.Kafka.Topics.CourierRequested
 	CourierAssignedTopic = config.Cfg.Kafka.Topics.CourierAssigned
 	CourierSearchFailedTopic = config.Cfg.Kafka.Topics.CourierSearchFailed
@@ -59,11 +87,25 @@ func InitTopicsNames() {
 		OrderPaidTopic,
 		OrderPickedUpTopic,
 		OrderDeliveredTopic,
+		OrderCancelledTopic,
+		OrderExpiredTopic,
+
+		OrderAcceptedTopic,
+		OrderRejectedTopic,
+		OrderPreparingTopic,
+		OrderReadyForPickupTopic,
+
+		OrderReleaseRequestedTopic,
+		OrderExpiryRequestedTopic,
 
 		PaymentSucceededTopic,
 		PaymentFailedTopic,
 		PaymentRequestedTopic,
 
+		RefundRequestedTopic,
+		RefundSucceededTopic,
+		RefundFailedTopic,
+
 		CourierRequestedTopic,
 		CourierAssignedTopic,
 		CourierSearchFailedTopic,
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/store"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// Idempotency makes retries of a request with the same Idempotency-Key header
+// safe. Keys are scoped per user: a repeat with the same body gets the stored
+// response of the first request, a repeat with a different body gets 409 and
+// a repeat on another method or path gets 422. Requests without the header are
+// passed through unchanged.
+func Idempotency(idempotencyStore *store.IdempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			userID := r.Header.Get("X-User-Id")
+			if key == "" || userID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			requestRoute := r.Method + " " + r.URL.Path
+
+			hash := sha256.New()
+			hash.Write([]byte(requestRoute + "\n"))
+			hash.Write(body)
+			requestHash := hex.EncodeToString(hash.Sum(nil))
+
+			existing, claimed, err := idempotencyStore.Claim(r.Context(), userID, key, requestRoute, requestHash, ttl)
+			if err != nil {
+				slog.Error("failed to claim idempotency key", "user_id", userID, "error", err)
+				http.Error(w, "Error checking idempotency key", http.StatusInternalServerError)
+				return
+			}
+
+			if !claimed {
+				switch {
+				case existing.RequestRoute != requestRoute:
+					http.Error(w, "Idempotency-Key was already used for a different request", http.StatusUnprocessableEntity)
+				case existing.RequestHash != requestHash:
+					http.Error(w, "Idempotency-Key was already used with a different request body", http.StatusConflict)
+				case existing.StatusCode == nil:
+					http.Error(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+				default:
+					if existing.ContentType != "" {
+						w.Header().Set("Content-Type", existing.ContentType)
+					}
+					w.Header().Set("Idempotent-Replayed", "true")
+					w.WriteHeader(*existing.StatusCode)
+					w.Write(existing.ResponseBody)
+				}
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+			completed := false
+			defer func() {
+				if !completed {
+					if err := idempotencyStore.Release(context.WithoutCancel(r.Context()), userID, key); err != nil {
+						slog.Error("failed to release idempotency key", "user_id", userID, "error", err)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			// server errors are not stored so that the client can retry them
+			if rec.statusCode >= http.StatusInternalServerError {
+				return
+			}
+
+			err = idempotencyStore.SaveResponse(context.WithoutCancel(r.Context()), userID, key, rec.statusCode, rec.Header().Get("Content-Type"), rec.body.Bytes())
+			if err != nil {
+				slog.Error("failed to save idempotent response", "user_id", userID, "error", err)
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// StartIdempotencyKeysCleanup periodically deletes expired idempotency keys until ctx is cancelled.
+func StartIdempotencyKeysCleanup(ctx context.Context, idempotencyStore *store.IdempotencyStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := idempotencyStore.DeleteExpired(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					slog.Error("failed to delete expired idempotency keys", "error", err)
+				}
+				continue
+			}
+			if deleted > 0 {
+				slog.Info("expired idempotency keys deleted", "count", deleted)
+			}
+		}
+	}
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
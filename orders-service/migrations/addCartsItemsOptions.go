@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddCartsItemsOptions adds the options chosen for cart items. The same menu
+// item can be in a cart once per set of options, so items get their own id
+// and the options become part of the item's uniqueness.
+func AddCartsItemsOptions(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var columnExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'carts_items' AND column_name = 'options');").
+		Scan(&columnExists)
+
+	if err != nil {
+		slog.Error("failed to check carts_items options column existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !columnExists {
+		_, err = tx.Exec(ctx, `
+			ALTER TABLE carts_items ADD COLUMN id UUID NOT NULL DEFAULT gen_random_uuid();
+			ALTER TABLE carts_items ADD COLUMN options TEXT[] NOT NULL DEFAULT '{}';
+			ALTER TABLE carts_items DROP CONSTRAINT carts_items_pkey;
+			ALTER TABLE carts_items ADD PRIMARY KEY (id);
+			ALTER TABLE carts_items ADD CONSTRAINT carts_items_cart_id_menu_item_id_options_key UNIQUE (cart_id, menu_item_id, options);
+		`)
+		if err != nil {
+			slog.Error("failed to add carts_items options column", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("carts_items options column added successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
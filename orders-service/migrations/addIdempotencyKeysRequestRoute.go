@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddIdempotencyKeysRequestRoute adds the method and path of the request a
+// key was first used for, so reusing it on another endpoint can be told apart
+// from a changed body.
+func AddIdempotencyKeysRequestRoute(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS request_route VARCHAR(255) NOT NULL DEFAULT '';
+	`)
+	if err != nil {
+		slog.Error("failed to add idempotency_keys request_route column", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersDeliverAt adds the requested delivery time of scheduled orders and
+// the time they are released to payment.
+func AddOrdersDeliverAt(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMPTZ;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS release_at TIMESTAMPTZ;
+
+		CREATE INDEX IF NOT EXISTS idx_orders_scheduled_release_at ON orders (release_at)
+			WHERE status = 'scheduled';
+	`)
+	if err != nil {
+		slog.Error("failed to add orders deliver_at columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersDeliveryAddress adds where the order is delivered to. Orders placed
+// before addresses were collected have none.
+func AddOrdersDeliveryAddress(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_address JSONB;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders delivery_address column", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersDiscount adds the price before discounts and the promotion applied
+// to an order. Orders placed before promotions existed keep their total as
+// subtotal.
+func AddOrdersDiscount(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal BIGINT;
+		UPDATE orders SET subtotal = total_price WHERE subtotal IS NULL;
+		ALTER TABLE orders ALTER COLUMN subtotal SET NOT NULL;
+
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS promotion_id UUID REFERENCES promotions(id);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code VARCHAR(64);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_type VARCHAR(20);
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders discount columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersFees adds the fees and tax that make up the total price of an
+// order next to its subtotal and discount.
+func AddOrdersFees(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_fee BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_fee BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS small_order_fee BIGINT NOT NULL DEFAULT 0;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders fee columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersItemsSnapshot adds the name and description the menu item had when
+// it was ordered and the options chosen for it. Items ordered before keep
+// empty values, their menu items may no longer exist.
+func AddOrdersItemsSnapshot(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders_items ADD COLUMN IF NOT EXISTS name TEXT NOT NULL DEFAULT '';
+		ALTER TABLE orders_items ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+		ALTER TABLE orders_items ADD COLUMN IF NOT EXISTS options TEXT[] NOT NULL DEFAULT '{}';
+	`)
+	if err != nil {
+		slog.Error("failed to add orders items snapshot columns", "error", err)
+		os.Exit(1)
+	}
+}
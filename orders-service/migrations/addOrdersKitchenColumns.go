@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersKitchenColumns adds the columns used once a restaurant accepts an
+// order: when the food should be ready and when a courier has to be requested.
+func AddOrdersKitchenColumns(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_ready_at TIMESTAMPTZ;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS courier_dispatch_at TIMESTAMPTZ;
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS courier_requested_at TIMESTAMPTZ;
+
+		CREATE INDEX IF NOT EXISTS idx_orders_courier_dispatch_at ON orders (courier_dispatch_at)
+			WHERE courier_requested_at IS NULL AND courier_id IS NULL;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders kitchen columns", "error", err)
+		os.Exit(1)
+	}
+}
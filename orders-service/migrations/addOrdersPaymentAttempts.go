@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersPaymentAttempts adds the number of payments requested for the
+// order, which payments-service uses to recognize duplicate requests.
+func AddOrdersPaymentAttempts(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_attempts INT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders payment_attempts column", "error", err)
+		os.Exit(1)
+	}
+}
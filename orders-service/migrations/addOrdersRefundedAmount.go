@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddOrdersRefundedAmount adds the part of the total price given back to the
+// user, in minor units of the order currency.
+func AddOrdersRefundedAmount(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add orders refunded_amount column", "error", err)
+		os.Exit(1)
+	}
+}
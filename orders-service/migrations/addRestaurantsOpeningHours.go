@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddRestaurantsOpeningHours adds the restaurant timezone and opening hours
+// to the local restaurants cache, used to validate scheduled delivery times.
+func AddRestaurantsOpeningHours(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS opening_hours JSONB NOT NULL DEFAULT '[]';
+	`)
+	if err != nil {
+		slog.Error("failed to add restaurants opening hours columns", "error", err)
+		os.Exit(1)
+	}
+}
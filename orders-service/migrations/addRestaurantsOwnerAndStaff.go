@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddRestaurantsOwnerAndStaff adds the restaurant owner and staff to the local
+// restaurants cache. Rows cached before get them with the next restaurant.updated event.
+func AddRestaurantsOwnerAndStaff(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS owner_id UUID;
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS staff_ids UUID[] NOT NULL DEFAULT '{}';
+	`)
+	if err != nil {
+		slog.Error("failed to add restaurants owner and staff columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateCartsTables(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'carts');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check carts table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE carts (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				user_id UUID NOT NULL,
+				restaurant_id UUID NOT NULL REFERENCES restaurants(id) ON DELETE CASCADE,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				UNIQUE (user_id, restaurant_id)
+			);
+
+			CREATE TABLE carts_items (
+				cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+				menu_item_id UUID NOT NULL,
+				quantity INT NOT NULL CHECK (quantity > 0),
+				price BIGINT NOT NULL,
+				currency VARCHAR(3) NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				PRIMARY KEY (cart_id, menu_item_id)
+			);
+		`)
+		if err != nil {
+			slog.Error("failed to create carts tables", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("carts tables created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
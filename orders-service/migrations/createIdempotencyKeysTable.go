@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateIdempotencyKeysTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'idempotency_keys');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check idempotency_keys table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE idempotency_keys (
+				user_id UUID NOT NULL,
+				key VARCHAR(255) NOT NULL,
+				request_hash VARCHAR(64) NOT NULL,
+				status_code INT,
+				content_type VARCHAR(255),
+				response_body BYTEA,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				expires_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (user_id, key)
+			);
+
+			CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+		`)
+		if err != nil {
+			slog.Error("failed to create idempotency_keys table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("idempotency_keys table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateOrderStatusHistoryTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'order_status_history');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check order_status_history table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE order_status_history (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL,
+				from_status VARCHAR(50),
+				to_status VARCHAR(50) NOT NULL,
+				source VARCHAR(100) NOT NULL,
+				actor VARCHAR(100) NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+			);
+
+			CREATE INDEX idx_order_status_history_order_id ON order_status_history (order_id, created_at);
+		`)
+		if err != nil {
+			slog.Error("failed to create order_status_history table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("order_status_history table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateOrdersIndexes(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	// the orders listing is sorted by (created_at, id) and filtered by the columns below
+	_, err := db.Exec(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders (created_at DESC, id DESC);
+		CREATE INDEX IF NOT EXISTS idx_orders_status_created_at ON orders (status, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_orders_restaurant_id_created_at ON orders (restaurant_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders (user_id, created_at DESC);
+		CREATE INDEX IF NOT EXISTS idx_orders_courier_id_created_at ON orders (courier_id, created_at DESC);
+	`)
+	if err != nil {
+		slog.Error("failed to create orders indexes", "error", err)
+		os.Exit(1)
+	}
+}
@@ -34,7 +34,8 @@ func CreateOrdersItemsTable(db *pgxpool.Pool) {
 				order_id UUID NOT NULL,
 				menu_item_id UUID NOT NULL,
 				quantity INT NOT NULL,
-				price NUMERIC(10, 2) NOT NULL,
+				price BIGINT NOT NULL,
+				currency VARCHAR(3) NOT NULL,
 				CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
 			);
 		`)
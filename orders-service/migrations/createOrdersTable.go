@@ -33,7 +33,8 @@ func CreateOrdersTable(db *pgxpool.Pool) {
 				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 				restaurant_id UUID NOT NULL,
 				user_id UUID NOT NULL,
-				total_price NUMERIC(10, 2) NOT NULL,
+				total_price BIGINT NOT NULL,
+				currency VARCHAR(3) NOT NULL,
 				courier_id UUID,
 				status VARCHAR(50) NOT NULL,
 				retry_count INT NOT NULL DEFAULT 0,
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateOutboxIndexes(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	// the relay looks for older unsent events of the same key, and the cleanup
+	// deletes by sent_at
+	_, err := db.Exec(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_outbox_unsent_key_id ON outbox (key, id) WHERE sent_at IS NULL;
+		CREATE INDEX IF NOT EXISTS idx_outbox_sent_at ON outbox (sent_at) WHERE sent_at IS NOT NULL;
+	`)
+	if err != nil {
+		slog.Error("failed to create outbox indexes", "error", err)
+		os.Exit(1)
+	}
+}
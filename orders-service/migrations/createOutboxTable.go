@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateOutboxTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'outbox');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check outbox table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE outbox (
+				id BIGSERIAL PRIMARY KEY,
+				topic VARCHAR(255) NOT NULL,
+				key VARCHAR(255) NOT NULL,
+				payload BYTEA NOT NULL,
+				attempts INT NOT NULL DEFAULT 0,
+				last_error TEXT,
+				next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				sent_at TIMESTAMPTZ
+			);
+
+			CREATE INDEX idx_outbox_unsent ON outbox (id) WHERE sent_at IS NULL;
+		`)
+		if err != nil {
+			slog.Error("failed to create outbox table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("outbox table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
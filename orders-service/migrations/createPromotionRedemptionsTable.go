@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreatePromotionRedemptionsTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'promotion_redemptions');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check promotion_redemptions table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE promotion_redemptions (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				promotion_id UUID NOT NULL REFERENCES promotions(id),
+				order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+				user_id UUID NOT NULL,
+				discount BIGINT NOT NULL,
+				currency VARCHAR(3) NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+
+			CREATE INDEX idx_promotion_redemptions_promotion_user ON promotion_redemptions (promotion_id, user_id);
+		`)
+		if err != nil {
+			slog.Error("failed to create promotion_redemptions table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("promotion_redemptions table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreatePromotionsTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'promotions');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check promotions table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE promotions (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				code VARCHAR(64) NOT NULL UNIQUE,
+				type VARCHAR(20) NOT NULL,
+				percent_off INT,
+				amount_off BIGINT,
+				min_order_value BIGINT,
+				currency VARCHAR(3),
+				restaurant_id UUID,
+				starts_at TIMESTAMPTZ,
+				ends_at TIMESTAMPTZ,
+				max_redemptions INT,
+				max_redemptions_per_user INT,
+				redemptions_count INT NOT NULL DEFAULT 0,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+		`)
+		if err != nil {
+			slog.Error("failed to create promotions table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("promotions table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateRefundsTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'refunds');").
+		Scan(&tableExists)
+
+	if err != nil {
+		slog.Error("failed to check refunds table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		// order_item_id and quantity are only set for refunds of single items
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE refunds (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				order_item_id UUID REFERENCES orders_items(id) ON DELETE CASCADE,
+				quantity INT,
+				amount BIGINT NOT NULL CHECK (amount > 0),
+				currency VARCHAR(3) NOT NULL,
+				reason VARCHAR(255) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				failure_reason VARCHAR(255),
+				requested_by VARCHAR(100) NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds(order_id);
+		`)
+		if err != nil {
+			slog.Error("failed to create refunds table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("refunds table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
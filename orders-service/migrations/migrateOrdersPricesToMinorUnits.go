@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MigrateOrdersPricesToMinorUnits converts orders and orders_items created
+// with NUMERIC(10, 2) prices to integer minor units with an explicit currency.
+// Existing prices are assumed to be USD.
+func MigrateOrdersPricesToMinorUnits(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var dataType string
+	err = tx.QueryRow(ctx,
+		"SELECT data_type FROM information_schema.columns WHERE table_name = 'orders' AND column_name = 'total_price';").
+		Scan(&dataType)
+	if err != nil {
+		slog.Error("failed to check orders total_price column type", "error", err)
+		os.Exit(1)
+	}
+
+	if dataType != "numeric" {
+		return
+	}
+
+	_, err = tx.Exec(ctx, `
+		ALTER TABLE orders ALTER COLUMN total_price TYPE BIGINT USING ROUND(total_price * 100);
+		ALTER TABLE orders ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+		ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
+
+		ALTER TABLE orders_items ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+		ALTER TABLE orders_items ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+		ALTER TABLE orders_items ALTER COLUMN currency DROP DEFAULT;
+	`)
+	if err != nil {
+		slog.Error("failed to migrate order prices to minor units", "error", err)
+		os.Exit(1)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to commit transaction", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("order prices migrated to minor units")
+}
@@ -4,6 +4,29 @@ import "github.com/jackc/pgx/v5/pgxpool"
 
 func Migrate(db *pgxpool.Pool) {
 	CreateRestaurantsTable(db)
+	AddRestaurantsOwnerAndStaff(db)
+	AddRestaurantsOpeningHours(db)
+	AddRestaurantsLocation(db)
 	CreateOrdersTable(db)
+	CreateOrdersIndexes(db)
 	CreateOrdersItemsTable(db)
+	MigrateOrdersPricesToMinorUnits(db)
+	AddOrdersItemsSnapshot(db)
+	AddOrdersKitchenColumns(db)
+	AddOrdersDeliverAt(db)
+	CreatePromotionsTable(db)
+	AddOrdersDiscount(db)
+	AddOrdersFees(db)
+	AddOrdersDeliveryAddress(db)
+	AddOrdersRefundedAmount(db)
+	AddOrdersPaymentAttempts(db)
+	CreatePromotionRedemptionsTable(db)
+	CreateOrderStatusHistoryTable(db)
+	CreateCartsTables(db)
+	AddCartsItemsOptions(db)
+	CreateRefundsTable(db)
+	CreateOutboxTable(db)
+	CreateOutboxIndexes(db)
+	CreateIdempotencyKeysTable(db)
+	AddIdempotencyKeysRequestRoute(db)
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+// Cart is the order a user is putting together in one restaurant. A user has
+// at most one cart per restaurant; it is removed on checkout.
+type Cart struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	RestaurantID string     `json:"restaurant_id"`
+	Items        []CartItem `json:"items"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// CartItem keeps the price the item had when it was put into the cart.
+// Prices are checked against the menu again on checkout. A menu item is in
+// the cart once per set of options, which are kept sorted.
+type CartItem struct {
+	ID         string      `json:"id"`
+	MenuItemID string      `json:"menu_item_id"`
+	Options    []string    `json:"options"`
+	Quantity   int         `json:"quantity"`
+	Price      money.Money `json:"price"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// IdempotencyKey is a stored Idempotency-Key claim. StatusCode is nil while
+// the first request with the key is still being processed.
+type IdempotencyKey struct {
+	UserID       string
+	Key          string
+	RequestRoute string
+	RequestHash  string
+	StatusCode   *int
+	ContentType  string
+	ResponseBody []byte
+}
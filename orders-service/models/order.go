@@ -3,27 +3,48 @@ package models
 import (
 	"database/sql"
 	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/address"
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 )
 
 type Order struct {
-	ID            string         `json:"id"`
-	RestaurantID  string         `json:"restaurant_id"`
-	UserID        string         `json:"user_id"`
-	TotalPrice    float64        `json:"total_price"`
-	Status        string         `json:"status"`
-	RetryCount    int            `json:"retry_count"`
-	MaxRetryCount int            `json:"max_retry_count"`
-	NextRetryAt   time.Time      `json:"next_retry_at"`
-	CourierID     sql.NullString `json:"courier_id,omitempty"`
-	Items         []OrderItem    `json:"items"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID           string `json:"id"`
+	RestaurantID string `json:"restaurant_id"`
+	UserID       string `json:"user_id"`
+	Pricing
+	// RefundedAmount is the part of TotalPrice given back to the user so far.
+	RefundedAmount money.Money `json:"refunded_amount"`
+	Status         string      `json:"status"`
+	// PaymentAttempts is the number of payments requested for the order. Each
+	// request carries its number, so payments-service charges it only once.
+	PaymentAttempts int       `json:"payment_attempts"`
+	RetryCount      int       `json:"retry_count"`
+	MaxRetryCount   int       `json:"max_retry_count"`
+	NextRetryAt     time.Time `json:"next_retry_at"`
+	// DeliveryAddress is missing on orders placed before addresses were collected.
+	DeliveryAddress *address.Address `json:"delivery_address,omitempty"`
+	// DeliverAt is the delivery time requested for a scheduled order.
+	DeliverAt *time.Time `json:"deliver_at,omitempty"`
+	// ReleaseAt is when a scheduled order is released to payment.
+	ReleaseAt *time.Time `json:"-"`
+	// EstimatedReadyAt is set by the restaurant when it accepts the order.
+	EstimatedReadyAt *time.Time     `json:"estimated_ready_at,omitempty"`
+	CourierID        sql.NullString `json:"courier_id,omitempty"`
+	Items            []OrderItem    `json:"items"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
 }
 
+// OrderItem keeps the menu item as it was when the order was placed, so the
+// order stays readable after the restaurant renames or deletes the item.
 type OrderItem struct {
-	ID         string  `json:"id,omitempty"`
-	OrderID    string  `json:"order_id,omitempty"`
-	MenuItemID string  `json:"menu_item_id"`
-	Quantity   int     `json:"quantity"`
-	Price      float64 `json:"price"`
+	ID          string      `json:"id,omitempty"`
+	OrderID     string      `json:"order_id,omitempty"`
+	MenuItemID  string      `json:"menu_item_id"`
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Options     []string    `json:"options,omitempty"`
+	Quantity    int         `json:"quantity"`
+	Price       money.Money `json:"price"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type OrderFilter struct {
+	Status string
+	// Statuses, when set, limits the orders to the listed statuses.
+	Statuses     []string
+	RestaurantID string
+	UserID       string
+	CourierID    string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Cursor       string
+	Limit        int
+}
+
+type OrderPage struct {
+	Orders     []Order `json:"orders"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
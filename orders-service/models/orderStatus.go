@@ -0,0 +1,162 @@
+package models
+
+import "slices"
+
+const (
+	OrderStatusScheduled            = "scheduled"
+	OrderStatusPending              = "pending"
+	OrderStatusPaid                 = "paid"
+	OrderStatusPaymentFailed        = "payment_failed"
+	OrderStatusAccepted             = "accepted"
+	OrderStatusRejected             = "rejected"
+	OrderStatusPreparing            = "preparing"
+	OrderStatusReadyForPickup       = "ready_for_pickup"
+	OrderStatusNoCouriersAvailable  = "no_couriers_available"
+	OrderStatusRetriesCountExceeded = "retries_count_exceeded"
+	OrderStatusAwaitingPickup       = "awaiting_pickup"
+	OrderStatusPickedUp             = "picked_up"
+	OrderStatusDelivered            = "delivered"
+	OrderStatusCancelled            = "cancelled"
+	OrderStatusExpired              = "expired"
+	OrderStatusRefunded             = "refunded"
+)
+
+// orderStatusTransitions is the order state machine: every status maps to the
+// statuses an order is allowed to move to next. Statuses without outgoing
+// transitions are final.
+var orderStatusTransitions = map[string][]string{
+	// scheduled orders wait for scheduler-service to release them to payment
+	OrderStatusScheduled: {
+		OrderStatusPending,
+		OrderStatusCancelled,
+	},
+	// orders left unpaid for unpaid_orders.ttl are expired by scheduler-service
+	OrderStatusPending: {
+		OrderStatusPaid,
+		OrderStatusPaymentFailed,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+	},
+	OrderStatusPaymentFailed: {
+		OrderStatusPaid,
+		OrderStatusPaymentFailed,
+		OrderStatusCancelled,
+		OrderStatusExpired,
+	},
+	// the restaurant has to confirm a paid order before it is cooked
+	OrderStatusPaid: {
+		OrderStatusAccepted,
+		OrderStatusRejected,
+		OrderStatusCancelled,
+	},
+	// courier search runs alongside cooking, so courier statuses are reachable
+	// from every kitchen status; a pick up also means the food was ready
+	OrderStatusAccepted: {
+		OrderStatusPreparing,
+		OrderStatusReadyForPickup,
+		OrderStatusPickedUp,
+		OrderStatusRetriesCountExceeded,
+		OrderStatusCancelled,
+	},
+	OrderStatusPreparing: {
+		OrderStatusReadyForPickup,
+		OrderStatusPickedUp,
+		OrderStatusRetriesCountExceeded,
+		OrderStatusCancelled,
+	},
+	OrderStatusReadyForPickup: {
+		OrderStatusPickedUp,
+		OrderStatusRetriesCountExceeded,
+		OrderStatusCancelled,
+	},
+	// no_couriers_available and awaiting_pickup are only reached by orders
+	// paid before restaurants confirmed orders
+	OrderStatusNoCouriersAvailable: {
+		OrderStatusAwaitingPickup,
+		OrderStatusNoCouriersAvailable,
+		OrderStatusRetriesCountExceeded,
+		OrderStatusCancelled,
+	},
+	OrderStatusRetriesCountExceeded: {
+		OrderStatusCancelled,
+	},
+	// once the courier has picked the order up it can only be delivered
+	OrderStatusAwaitingPickup: {
+		OrderStatusPickedUp,
+		OrderStatusCancelled,
+	},
+	OrderStatusPickedUp: {
+		OrderStatusDelivered,
+	},
+	// orders whose payment has been given back in full end up refunded
+	OrderStatusDelivered: {
+		OrderStatusRefunded,
+	},
+	OrderStatusCancelled: {
+		OrderStatusRefunded,
+	},
+	OrderStatusRejected: {
+		OrderStatusRefunded,
+	},
+	OrderStatusExpired:  {},
+	OrderStatusRefunded: {},
+}
+
+// PreviousStatuses returns every status an order may be moved to the given one from.
+func PreviousStatuses(to string) []string {
+	var statuses []string
+	for from, next := range orderStatusTransitions {
+		if slices.Contains(next, to) {
+			statuses = append(statuses, from)
+		}
+	}
+	slices.Sort(statuses)
+
+	return statuses
+}
+
+// IsOrderStatus reports whether status is one of the known order statuses.
+func IsOrderStatus(status string) bool {
+	_, ok := orderStatusTransitions[status]
+	return ok
+}
+
+// IsUnfulfilledStatus reports whether the order will never be delivered.
+func IsUnfulfilledStatus(status string) bool {
+	return slices.Contains([]string{OrderStatusCancelled, OrderStatusRejected, OrderStatusExpired}, status)
+}
+
+// RestaurantLiveStatuses are the statuses of paid orders the restaurant still
+// has to cook or hand over to a courier.
+var RestaurantLiveStatuses = []string{
+	OrderStatusPaid,
+	OrderStatusAccepted,
+	OrderStatusPreparing,
+	OrderStatusReadyForPickup,
+	OrderStatusNoCouriersAvailable,
+	OrderStatusRetriesCountExceeded,
+	OrderStatusAwaitingPickup,
+}
+
+// RefundableStatuses are the statuses of paid orders whose payment has not
+// been given back yet. Cancelling or rejecting an order in one of them
+// refunds what is left of its payment.
+var RefundableStatuses = []string{
+	OrderStatusPaid,
+	OrderStatusAccepted,
+	OrderStatusPreparing,
+	OrderStatusReadyForPickup,
+	OrderStatusNoCouriersAvailable,
+	OrderStatusRetriesCountExceeded,
+	OrderStatusAwaitingPickup,
+	OrderStatusPickedUp,
+	OrderStatusDelivered,
+}
+
+// KitchenStatuses are the statuses of an order accepted by the restaurant and
+// not yet picked up. Courier dispatch and assignment do not change them.
+var KitchenStatuses = []string{
+	OrderStatusAccepted,
+	OrderStatusPreparing,
+	OrderStatusReadyForPickup,
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// StatusSourceAPI marks status changes made through the orders HTTP API
+// rather than by a Kafka event.
+const StatusSourceAPI = "api"
+
+type OrderStatusChange struct {
+	ID         string    `json:"id"`
+	OrderID    string    `json:"order_id"`
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Source     string    `json:"source"`
+	Actor      string    `json:"actor"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func UserActor(userID string) string {
+	return "user:" + userID
+}
+
+func RestaurantActor(restaurantID string) string {
+	return "restaurant:" + restaurantID
+}
+
+func CourierActor(courierID string) string {
+	return "courier:" + courierID
+}
+
+func ServiceActor(service string) string {
+	return "service:" + service
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent is a Kafka message stored in the outbox table in the same
+// transaction as the state change it announces.
+type OutboxEvent struct {
+	ID        int64
+	Topic     string
+	Key       string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+func NewOutboxEvent(topic, key string, payload any) (OutboxEvent, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return OutboxEvent{}, err
+	}
+
+	return OutboxEvent{
+		Topic:   topic,
+		Key:     key,
+		Payload: body,
+	}, nil
+}
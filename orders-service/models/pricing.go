@@ -0,0 +1,18 @@
+package models
+
+import "github.com/MatTwix/Food-Delivery-Agregator/common/money"
+
+// Pricing is how the total price of an order is made up. All amounts share
+// the currency of the items.
+type Pricing struct {
+	// Subtotal is the price of the items.
+	Subtotal    money.Money `json:"subtotal"`
+	DeliveryFee money.Money `json:"delivery_fee"`
+	ServiceFee  money.Money `json:"service_fee"`
+	// SmallOrderFee is charged when the subtotal is below the configured threshold.
+	SmallOrderFee money.Money    `json:"small_order_fee"`
+	Discount      *OrderDiscount `json:"discount,omitempty"`
+	Tax           money.Money    `json:"tax"`
+	// TotalPrice is what the user pays.
+	TotalPrice money.Money `json:"total_price"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+const (
+	PromotionTypePercentage   = "percentage"
+	PromotionTypeFixedAmount  = "fixed_amount"
+	PromotionTypeFreeDelivery = "free_delivery"
+)
+
+// PromotionError explains to the user why a promo code cannot be used for
+// an order.
+type PromotionError struct {
+	Reason string
+}
+
+func (e *PromotionError) Error() string {
+	return e.Reason
+}
+
+func promotionError(format string, args ...any) *PromotionError {
+	return &PromotionError{Reason: fmt.Sprintf(format, args...)}
+}
+
+type Promotion struct {
+	ID   string `json:"id"`
+	Code string `json:"code"`
+	Type string `json:"type"`
+	// PercentOff is the discount of percentage promotions, 1 to 100.
+	PercentOff int `json:"percent_off,omitempty"`
+	// AmountOff is the discount of fixed amount promotions.
+	AmountOff     *money.Money `json:"amount_off,omitempty"`
+	MinOrderValue *money.Money `json:"min_order_value,omitempty"`
+	// RestaurantID limits the promotion to one restaurant.
+	RestaurantID *string    `json:"restaurant_id,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	// MaxRedemptions and MaxRedemptionsPerUser are unlimited when nil.
+	MaxRedemptions        *int      `json:"max_redemptions,omitempty"`
+	MaxRedemptionsPerUser *int      `json:"max_redemptions_per_user,omitempty"`
+	RedemptionsCount      int       `json:"redemptions_count"`
+	IsActive              bool      `json:"is_active"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// OrderDiscount is the promotion applied to an order.
+type OrderDiscount struct {
+	PromotionID string      `json:"promotion_id"`
+	Code        string      `json:"code"`
+	Type        string      `json:"type"`
+	Amount      money.Money `json:"amount"`
+}
+
+// Discount checks that the promotion can be used for an order of the
+// restaurant at now and returns the amount it takes off. Free delivery
+// promotions take off the delivery fee. Usage limits are enforced when the
+// redemption is stored.
+func (p Promotion) Discount(subtotal, deliveryFee money.Money, restaurantID string, now time.Time) (money.Money, error) {
+	noDiscount := money.New(0, subtotal.Currency)
+
+	if !p.IsActive {
+		return noDiscount, promotionError("Promo code %s is no longer active", p.Code)
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return noDiscount, promotionError("Promo code %s is valid from %s", p.Code, p.StartsAt.Format(time.RFC3339))
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return noDiscount, promotionError("Promo code %s expired at %s", p.Code, p.EndsAt.Format(time.RFC3339))
+	}
+	if p.RestaurantID != nil && *p.RestaurantID != restaurantID {
+		return noDiscount, promotionError("Promo code %s is not valid for this restaurant", p.Code)
+	}
+	if p.MaxRedemptions != nil && p.RedemptionsCount >= *p.MaxRedemptions {
+		return noDiscount, promotionError("Promo code %s has reached its usage limit", p.Code)
+	}
+
+	if p.MinOrderValue != nil {
+		if p.MinOrderValue.Currency != subtotal.Currency {
+			return noDiscount, promotionError("Promo code %s is only valid for orders in %s", p.Code, p.MinOrderValue.Currency)
+		}
+		if subtotal.Amount < p.MinOrderValue.Amount {
+			return noDiscount, promotionError("Promo code %s requires a minimum order value of %s", p.Code, p.MinOrderValue)
+		}
+	}
+
+	switch p.Type {
+	case PromotionTypePercentage:
+		return money.New(subtotal.Amount*int64(p.PercentOff)/100, subtotal.Currency), nil
+	case PromotionTypeFixedAmount:
+		if p.AmountOff.Currency != subtotal.Currency {
+			return noDiscount, promotionError("Promo code %s is only valid for orders in %s", p.Code, p.AmountOff.Currency)
+		}
+		return money.New(min(p.AmountOff.Amount, subtotal.Amount), subtotal.Currency), nil
+	case PromotionTypeFreeDelivery:
+		return deliveryFee, nil
+	default:
+		return noDiscount, fmt.Errorf("unknown promotion type %q", p.Type)
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+const (
+	RefundStatusRequested = "requested"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund is money requested back from payments-service for an order.
+// Refunds of a single item carry the item and how many of it are refunded.
+type Refund struct {
+	ID            string      `json:"id"`
+	OrderID       string      `json:"order_id"`
+	OrderItemID   *string     `json:"order_item_id,omitempty"`
+	Quantity      *int        `json:"quantity,omitempty"`
+	Amount        money.Money `json:"amount"`
+	Reason        string      `json:"reason"`
+	Status        string      `json:"status"`
+	FailureReason *string     `json:"failure_reason,omitempty"`
+	// RequestedBy is the actor who asked for the refund, as in the order history.
+	RequestedBy string    `json:"requested_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
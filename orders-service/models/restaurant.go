@@ -1,12 +1,24 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/hours"
+)
 
 type Restaurant struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Address     string    `json:"address"`
-	PhoneNumber string    `json:"phone_number"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           string     `json:"id"`
+	OwnerID      string     `json:"owner_id"`
+	Name         string     `json:"name"`
+	Address      string     `json:"address"`
+	PhoneNumber  string     `json:"phone_number"`
+	StaffIDs     []string   `json:"staff_ids"`
+	Timezone     string     `json:"timezone"`
+	OpeningHours hours.Week `json:"opening_hours"`
+	// Latitude and Longitude locate the restaurant; nil if restaurants-service
+	// has none for it.
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
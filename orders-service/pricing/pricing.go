@@ -0,0 +1,77 @@
+package pricing
+
+import (
+	"math"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+)
+
+// Delivery fee modes.
+const (
+	DeliveryFeeFlat     = "flat"
+	DeliveryFeeDistance = "distance"
+)
+
+// DiscountFunc returns the discount of an order priced without it, or nil.
+type DiscountFunc func(p models.Pricing) (*models.OrderDiscount, error)
+
+// Price builds the price breakdown of an order from the item subtotal and
+// the pricing config. distanceKm is the delivery distance, 0 if unknown.
+// discount may be nil; it gets the fees so that it can take off the
+// delivery fee. Tax is charged on the discounted price including fees.
+func Price(subtotal money.Money, distanceKm float64, discount DiscountFunc) (models.Pricing, error) {
+	cfg := config.Cfg.Pricing
+	currency := subtotal.Currency
+
+	p := models.Pricing{
+		Subtotal:      subtotal,
+		DeliveryFee:   money.New(deliveryFee(distanceKm), currency),
+		ServiceFee:    money.New(rate(subtotal.Amount, cfg.ServiceFee.RateBps), currency),
+		SmallOrderFee: money.New(0, currency),
+	}
+
+	if subtotal.Amount < cfg.SmallOrder.Threshold {
+		p.SmallOrderFee.Amount = cfg.SmallOrder.Surcharge
+	}
+
+	if discount != nil {
+		d, err := discount(p)
+		if err != nil {
+			return p, err
+		}
+		p.Discount = d
+	}
+
+	taxable := subtotal.Amount + p.DeliveryFee.Amount + p.ServiceFee.Amount + p.SmallOrderFee.Amount
+	if p.Discount != nil {
+		taxable -= p.Discount.Amount.Amount
+	}
+
+	p.Tax = money.New(rate(taxable, cfg.Tax.RateBps), currency)
+	p.TotalPrice = money.New(taxable+p.Tax.Amount, currency)
+
+	return p, nil
+}
+
+// deliveryFee is the base fee, plus in distance mode the per kilometre fee
+// for every started kilometre beyond the free distance.
+func deliveryFee(distanceKm float64) int64 {
+	cfg := config.Cfg.Pricing.DeliveryFee
+	if cfg.Mode != DeliveryFeeDistance {
+		return cfg.Base
+	}
+
+	extraKm := math.Ceil(distanceKm - cfg.FreeKm)
+	if extraKm <= 0 {
+		return cfg.Base
+	}
+
+	return cfg.Base + cfg.PerKm*int64(extraKm)
+}
+
+// rate returns bps basis points of amount, rounded half up.
+func rate(amount, bps int64) int64 {
+	return (amount*bps + 5000) / 10000
+}
@@ -0,0 +1,291 @@
+package store
+
+import (
+	"context"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CartStore struct {
+	db *pgxpool.Pool
+}
+
+func NewCartStore(db *pgxpool.Pool) *CartStore {
+	return &CartStore{db: db}
+}
+
+// GetAll returns every cart of the user, most recently changed first.
+func (s *CartStore) GetAll(ctx context.Context, userID string) ([]models.Cart, error) {
+	query := `
+		SELECT id, user_id, restaurant_id, created_at, updated_at
+		FROM carts
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := s.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	carts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Cart, error) {
+		return scanCart(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadCartItems(ctx, s.db, carts); err != nil {
+		return nil, err
+	}
+
+	return carts, nil
+}
+
+// Get returns the cart of the user in the restaurant or pgx.ErrNoRows.
+func (s *CartStore) Get(ctx context.Context, userID, restaurantID string) (models.Cart, error) {
+	return getCart(ctx, s.db, userID, restaurantID)
+}
+
+// Replace sets the items of the user's cart in the restaurant, creating the
+// cart if needed.
+func (s *CartStore) Replace(ctx context.Context, userID, restaurantID string, items []models.CartItem) (models.Cart, error) {
+	return s.change(ctx, userID, restaurantID, func(tx pgx.Tx, cartID string) error {
+		if _, err := tx.Exec(ctx, "DELETE FROM carts_items WHERE cart_id = $1", cartID); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			if err := upsertCartItem(ctx, tx, cartID, item, false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// AddItem puts the item into the user's cart in the restaurant, adding to
+// its quantity if it is there already.
+func (s *CartStore) AddItem(ctx context.Context, userID, restaurantID string, item models.CartItem) (models.Cart, error) {
+	return s.change(ctx, userID, restaurantID, func(tx pgx.Tx, cartID string) error {
+		return upsertCartItem(ctx, tx, cartID, item, true)
+	})
+}
+
+// SetItemQuantity changes the quantity of an item already in the cart. It
+// returns pgx.ErrNoRows if the cart or the item does not exist.
+func (s *CartStore) SetItemQuantity(ctx context.Context, userID, restaurantID, itemID string, quantity int) (models.Cart, error) {
+	query := `
+		UPDATE carts_items AS ci
+		SET quantity = $4
+		FROM carts AS c
+		WHERE ci.cart_id = c.id AND c.user_id = $1 AND c.restaurant_id = $2 AND ci.id = $3
+	`
+
+	return s.changeItem(ctx, userID, restaurantID, query, itemID, quantity)
+}
+
+// RemoveItem takes the item out of the cart. It returns pgx.ErrNoRows if
+// the cart or the item does not exist.
+func (s *CartStore) RemoveItem(ctx context.Context, userID, restaurantID, itemID string) (models.Cart, error) {
+	query := `
+		DELETE FROM carts_items AS ci
+		USING carts AS c
+		WHERE ci.cart_id = c.id AND c.user_id = $1 AND c.restaurant_id = $2 AND ci.id = $3
+	`
+
+	return s.changeItem(ctx, userID, restaurantID, query, itemID)
+}
+
+// UpdatePrices stores the current menu prices of the cart items.
+func (s *CartStore) UpdatePrices(ctx context.Context, cartID string, prices map[string]money.Money) error {
+	query := `
+		UPDATE carts_items
+		SET price = $3, currency = $4
+		WHERE cart_id = $1 AND menu_item_id = $2
+	`
+
+	batch := &pgx.Batch{}
+	for menuItemID, price := range prices {
+		batch.Queue(query, cartID, menuItemID, price.Amount, price.Currency)
+	}
+
+	return s.db.SendBatch(ctx, batch).Close()
+}
+
+// Delete removes the user's cart in the restaurant. It reports false if
+// there was none.
+func (s *CartStore) Delete(ctx context.Context, userID, restaurantID string) (bool, error) {
+	query := `
+		DELETE FROM carts
+		WHERE user_id = $1 AND restaurant_id = $2
+	`
+
+	tag, err := s.db.Exec(ctx, query, userID, restaurantID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// change creates the cart if needed, applies apply to it and returns the
+// changed cart, all in one transaction.
+func (s *CartStore) change(ctx context.Context, userID, restaurantID string, apply func(tx pgx.Tx, cartID string) error) (models.Cart, error) {
+	query := `
+		INSERT INTO carts (user_id, restaurant_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, restaurant_id) DO UPDATE SET updated_at = NOW()
+		RETURNING id
+	`
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	var cartID string
+	if err := tx.QueryRow(ctx, query, userID, restaurantID).Scan(&cartID); err != nil {
+		return models.Cart{}, err
+	}
+
+	if err := apply(tx, cartID); err != nil {
+		return models.Cart{}, err
+	}
+
+	cart, err := getCart(ctx, tx, userID, restaurantID)
+	if err != nil {
+		return cart, err
+	}
+
+	return cart, tx.Commit(ctx)
+}
+
+// changeItem runs an item query taking user, restaurant and cart item ids
+// as $1 to $3 and returns the changed cart.
+func (s *CartStore) changeItem(ctx context.Context, userID, restaurantID, query, itemID string, args ...any) (models.Cart, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, append([]any{userID, restaurantID, itemID}, args...)...)
+	if err != nil {
+		return models.Cart{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		return models.Cart{}, pgx.ErrNoRows
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE carts SET updated_at = NOW() WHERE user_id = $1 AND restaurant_id = $2", userID, restaurantID)
+	if err != nil {
+		return models.Cart{}, err
+	}
+
+	cart, err := getCart(ctx, tx, userID, restaurantID)
+	if err != nil {
+		return cart, err
+	}
+
+	return cart, tx.Commit(ctx)
+}
+
+// querier is what reads need from both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func scanCart(row pgx.Row) (models.Cart, error) {
+	var cart models.Cart
+	err := row.Scan(&cart.ID, &cart.UserID, &cart.RestaurantID, &cart.CreatedAt, &cart.UpdatedAt)
+
+	return cart, err
+}
+
+func getCart(ctx context.Context, q querier, userID, restaurantID string) (models.Cart, error) {
+	query := `
+		SELECT id, user_id, restaurant_id, created_at, updated_at
+		FROM carts
+		WHERE user_id = $1 AND restaurant_id = $2
+	`
+
+	cart, err := scanCart(q.QueryRow(ctx, query, userID, restaurantID))
+	if err != nil {
+		return cart, err
+	}
+
+	carts := []models.Cart{cart}
+	err = loadCartItems(ctx, q, carts)
+
+	return carts[0], err
+}
+
+// loadCartItems fills Items of every given cart using a single batched query.
+func loadCartItems(ctx context.Context, q querier, carts []models.Cart) error {
+	if len(carts) == 0 {
+		return nil
+	}
+
+	cartIDs := make([]string, len(carts))
+	indexByID := make(map[string]int, len(carts))
+	for i, cart := range carts {
+		cartIDs[i] = cart.ID
+		indexByID[cart.ID] = i
+		carts[i].Items = []models.CartItem{}
+	}
+
+	query := `
+		SELECT cart_id, id, menu_item_id, options, quantity, price, currency
+		FROM carts_items
+		WHERE cart_id = ANY($1)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := q.Query(ctx, query, cartIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cartID string
+		var item models.CartItem
+		if err := rows.Scan(&cartID, &item.ID, &item.MenuItemID, &item.Options, &item.Quantity, &item.Price.Amount, &item.Price.Currency); err != nil {
+			return err
+		}
+
+		i := indexByID[cartID]
+		carts[i].Items = append(carts[i].Items, item)
+	}
+
+	return rows.Err()
+}
+
+// upsertCartItem stores the item, adding to or replacing the quantity of an
+// item with the same options already in the cart.
+func upsertCartItem(ctx context.Context, tx pgx.Tx, cartID string, item models.CartItem, addQuantity bool) error {
+	query := `
+		INSERT INTO carts_items (cart_id, menu_item_id, options, quantity, price, currency)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (cart_id, menu_item_id, options) DO UPDATE SET
+			quantity = CASE WHEN $7 THEN carts_items.quantity + EXCLUDED.quantity ELSE EXCLUDED.quantity END,
+			price = EXCLUDED.price,
+			currency = EXCLUDED.currency
+	`
+
+	options := item.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	_, err := tx.Exec(ctx, query, cartID, item.MenuItemID, options, item.Quantity, item.Price.Amount, item.Price.Currency, addQuantity)
+
+	return err
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyStore struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyStore(db *pgxpool.Pool) *IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+// Claim reserves the key for the user. It returns claimed=false together with
+// the existing record if an unexpired record for the key already exists.
+func (s *IdempotencyStore) Claim(ctx context.Context, userID, key, requestRoute, requestHash string, ttl time.Duration) (existing models.IdempotencyKey, claimed bool, err error) {
+	claimQuery := `
+		INSERT INTO idempotency_keys (user_id, key, request_route, request_hash, expires_at)
+		VALUES ($1, $2, $3, $4, NOW() + make_interval(secs => $5))
+		ON CONFLICT (user_id, key) DO UPDATE SET
+			request_route = EXCLUDED.request_route,
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+	`
+
+	result, err := s.db.Exec(ctx, claimQuery, userID, key, requestRoute, requestHash, ttl.Seconds())
+	if err != nil {
+		return existing, false, err
+	}
+
+	if result.RowsAffected() == 1 {
+		return existing, true, nil
+	}
+
+	existingQuery := `
+		SELECT user_id, key, request_route, request_hash, status_code, COALESCE(content_type, ''), response_body
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`
+
+	err = s.db.QueryRow(ctx, existingQuery, userID, key).Scan(
+		&existing.UserID,
+		&existing.Key,
+		&existing.RequestRoute,
+		&existing.RequestHash,
+		&existing.StatusCode,
+		&existing.ContentType,
+		&existing.ResponseBody,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the record expired and was purged in between, try again
+		return s.Claim(ctx, userID, key, requestRoute, requestHash, ttl)
+	}
+
+	return existing, false, err
+}
+
+func (s *IdempotencyStore) SaveResponse(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3
+		WHERE user_id = $4 AND key = $5
+	`
+
+	_, err := s.db.Exec(ctx, query, statusCode, contentType, body, userID, key)
+
+	return err
+}
+
+// Release drops a claim whose request did not complete, so the client can retry with the same key.
+func (s *IdempotencyStore) Release(ctx context.Context, userID, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2 AND status_code IS NULL
+	`
+
+	_, err := s.db.Exec(ctx, query, userID, key)
+
+	return err
+}
+
+func (s *IdempotencyStore) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= NOW()
+	`
+
+	result, err := s.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,37 @@
+package store
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Order listing cursors point at the last order of a page. They are opaque to
+// clients: base64 of "<created_at>|<id>".
+
+func encodeOrderCursor(createdAt time.Time, id string) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeOrderCursor(cursor string) (createdAt time.Time, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return createdAt, "", ErrInvalidCursor
+	}
+
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return createdAt, "", ErrInvalidCursor
+	}
+
+	createdAt, err = time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return createdAt, "", ErrInvalidCursor
+	}
+
+	return createdAt, id, nil
+}
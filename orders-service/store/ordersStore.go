@@ -3,140 +3,248 @@ package store
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderNotPayable         = errors.New("order can no longer be paid")
+	ErrCourierNotAssigned      = errors.New("order is not assigned to the courier")
+	ErrOrderNotExpired         = errors.New("order had a payment attempt within the unpaid ttl")
+	ErrCartChanged             = errors.New("cart was changed or checked out")
+)
+
 type OrderStore struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	rejected *transitionCounter
 }
 
 func NewOrderStore(db *pgxpool.Pool) *OrderStore {
-	return &OrderStore{db: db}
-}
-
-func (s *OrderStore) GetAll(ctx context.Context) ([]models.Order, error) {
-	orderQuery := `
-		SELECT
-		id, restaurant_id, user_id, total_price, status, courier_id, retry_count, max_retry_count, next_retry_at, created_at, updated_at
-		FROM
-		orders
-	`
-
-	var orders []models.Order
-
-	orderRows, err := s.db.Query(ctx, orderQuery)
-	if err != nil {
-		return orders, nil
-	}
-
-	for orderRows.Next() {
-		var order models.Order
-
-		err := orderRows.Scan(
-			&order.ID,
-			&order.RestaurantID,
-			&order.UserID,
-			&order.TotalPrice,
-			&order.Status,
-			&order.CourierID,
-			&order.RetryCount,
-			&order.MaxRetryCount,
-			&order.NextRetryAt,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
-
-		if err != nil {
-			return orders, err
-		}
-
-		itemsQuery := `
-			SELECT 
-			id, order_id, menu_item_id, quantity, price
-			FROM
-			orders_items
-			WHERE
-			order_id = $1
-		`
-
-		itemRows, err := s.db.Query(ctx, itemsQuery, order.ID)
-		if err != nil {
-			return orders, err
-		}
-
-		for itemRows.Next() {
-			var item models.OrderItem
-			if err := itemRows.Scan(&item.ID, &item.OrderID, &item.MenuItemID, &item.Quantity, &item.Price); err != nil {
-				return orders, err
-			}
-
-			order.Items = append(order.Items, item)
-		}
-
-		orders = append(orders, order)
+	return &OrderStore{
+		db:       db,
+		rejected: newTransitionCounter(),
 	}
-
-	return orders, nil
 }
 
-func (s *OrderStore) GetByID(ctx context.Context, id string) (models.Order, error) {
-	orderQuery := `
-		SELECT
-		id, restaurant_id, user_id, total_price, status, courier_id, retry_count, max_retry_count, next_retry_at, created_at, updated_at
-		FROM
-		orders
-		WHERE id = $1
-	`
+// orderColumns is the column list scanOrder expects.
+const orderColumns = `id, restaurant_id, user_id, subtotal, delivery_fee, service_fee, small_order_fee, promotion_id, promo_code, discount_type, discount, tax, total_price, refunded_amount, currency, status, payment_attempts, courier_id, retry_count, max_retry_count, next_retry_at, delivery_address, deliver_at, estimated_ready_at, created_at, updated_at`
+
+func scanOrder(row pgx.Row) (models.Order, error) {
 	var order models.Order
+	var promotionID, promoCode, discountType *string
+	var discount int64
 
-	err := s.db.QueryRow(ctx, orderQuery, id).Scan(
+	err := row.Scan(
 		&order.ID,
 		&order.RestaurantID,
 		&order.UserID,
-		&order.TotalPrice,
+		&order.Subtotal.Amount,
+		&order.DeliveryFee.Amount,
+		&order.ServiceFee.Amount,
+		&order.SmallOrderFee.Amount,
+		&promotionID,
+		&promoCode,
+		&discountType,
+		&discount,
+		&order.Tax.Amount,
+		&order.TotalPrice.Amount,
+		&order.RefundedAmount.Amount,
+		&order.TotalPrice.Currency,
 		&order.Status,
+		&order.PaymentAttempts,
 		&order.CourierID,
 		&order.RetryCount,
 		&order.MaxRetryCount,
 		&order.NextRetryAt,
+		&order.DeliveryAddress,
+		&order.DeliverAt,
+		&order.EstimatedReadyAt,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
 
+	for _, m := range []*money.Money{&order.Subtotal, &order.DeliveryFee, &order.ServiceFee, &order.SmallOrderFee, &order.Tax, &order.RefundedAmount} {
+		m.Currency = order.TotalPrice.Currency
+	}
+	if promotionID != nil {
+		order.Discount = &models.OrderDiscount{
+			PromotionID: *promotionID,
+			Code:        *promoCode,
+			Type:        *discountType,
+			Amount:      money.New(discount, order.TotalPrice.Currency),
+		}
+	}
+
+	return order, err
+}
+
+// queryOrders runs an orders query selecting orderColumns and loads the items
+// of all returned orders with one extra query.
+func (s *OrderStore) queryOrders(ctx context.Context, query string, args ...any) ([]models.Order, error) {
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
-		return order, err
+		return nil, err
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Order, error) {
+		return scanOrder(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadItems(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// loadItems fills Items of every given order using a single batched query.
+func (s *OrderStore) loadItems(ctx context.Context, orders []models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	orderIDs := make([]string, len(orders))
+	indexByID := make(map[string]int, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order.ID
+		indexByID[order.ID] = i
 	}
 
 	itemsQuery := `
-		SELECT 
-		id, order_id, menu_item_id, quantity, price
+		SELECT
+		id, order_id, menu_item_id, name, description, options, quantity, price, currency
 		FROM
 		orders_items
 		WHERE
-		order_id = $1
+		order_id = ANY($1)
 	`
 
-	rows, err := s.db.Query(ctx, itemsQuery, order.ID)
+	rows, err := s.db.Query(ctx, itemsQuery, orderIDs)
 	if err != nil {
-		return order, err
+		return err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var item models.OrderItem
-		if err := rows.Scan(&item.ID, &item.OrderID, &item.MenuItemID, &item.Quantity, &item.Price); err != nil {
-			return order, err
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.MenuItemID, &item.Name, &item.Description, &item.Options, &item.Quantity, &item.Price.Amount, &item.Price.Currency); err != nil {
+			return err
+		}
+
+		i := indexByID[item.OrderID]
+		orders[i].Items = append(orders[i].Items, item)
+	}
+
+	return rows.Err()
+}
+
+// List returns one page of orders matching the filter, newest first. Orders
+// are sorted by (created_at, id) so pages stay stable while new orders arrive.
+func (s *OrderStore) List(ctx context.Context, filter models.OrderFilter) (models.OrderPage, error) {
+	page := models.OrderPage{Orders: []models.Order{}}
+
+	var conditions []string
+	var args []any
+
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		addCondition("status = $%d", filter.Status)
+	}
+	if len(filter.Statuses) > 0 {
+		addCondition("status = ANY($%d)", filter.Statuses)
+	}
+	if filter.RestaurantID != "" {
+		addCondition("restaurant_id = $%d", filter.RestaurantID)
+	}
+	if filter.UserID != "" {
+		addCondition("user_id = $%d", filter.UserID)
+	}
+	if filter.CourierID != "" {
+		addCondition("courier_id = $%d", filter.CourierID)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= $%d", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < $%d", *filter.CreatedTo)
+	}
+	if filter.Cursor != "" {
+		createdAt, id, err := decodeOrderCursor(filter.Cursor)
+		if err != nil {
+			return page, err
 		}
+		args = append(args, createdAt, id)
+		conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	orderQuery := "SELECT " + orderColumns + " FROM orders"
+	if len(conditions) > 0 {
+		orderQuery += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// one extra row tells whether there is a next page
+	args = append(args, filter.Limit+1)
+	orderQuery += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := s.db.Query(ctx, orderQuery, args...)
+	if err != nil {
+		return page, err
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Order, error) {
+		return scanOrder(row)
+	})
+	if err != nil {
+		return page, err
+	}
+
+	if len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+		last := orders[len(orders)-1]
+		page.NextCursor = encodeOrderCursor(last.CreatedAt, last.ID)
+	}
+
+	if err := s.loadItems(ctx, orders); err != nil {
+		return page, err
+	}
+
+	if len(orders) > 0 {
+		page.Orders = orders
+	}
+
+	return page, nil
+}
+
+func (s *OrderStore) GetByID(ctx context.Context, id string) (models.Order, error) {
+	orderQuery := "SELECT " + orderColumns + " FROM orders WHERE id = $1"
+
+	order, err := scanOrder(s.db.QueryRow(ctx, orderQuery, id))
+	if err != nil {
+		return order, err
+	}
 
-		order.Items = append(order.Items, item)
+	orders := []models.Order{order}
+	if err := s.loadItems(ctx, orders); err != nil {
+		return order, err
 	}
 
-	return order, nil
+	return orders[0], nil
 }
 
 func (s *OrderStore) GetOwnerID(ctx context.Context, orderID string) (string, error) {
@@ -167,10 +275,21 @@ func (s *OrderStore) GetStatus(ctx context.Context, orderID string) (string, err
 	return status, err
 }
 
+func (s *OrderStore) GetRestaurantIDAndStatus(ctx context.Context, orderID string) (restaurantID, status string, err error) {
+	query := `
+		SELECT restaurant_id, status
+		FROM orders
+		WHERE id = $1
+	`
+
+	err = s.db.QueryRow(ctx, query, orderID).Scan(&restaurantID, &status)
+
+	return restaurantID, status, err
+}
+
 func (s *OrderStore) GetForRetry(ctx context.Context, status string, nextRetryAtLte int64, limit int32) ([]models.Order, error) {
 	orderQuery := `
-		SELECT
-		id, restaurant_id, user_id, total_price, status, courier_id, retry_count, max_retry_count, next_retry_at, created_at, updated_at
+		SELECT ` + orderColumns + `
 		FROM orders
 		WHERE status = $1 AND next_retry_at <= $2 AND retry_count < max_retry_count
 		ORDER BY next_retry_at ASC
@@ -185,107 +304,154 @@ func (s *OrderStore) GetForRetry(ctx context.Context, status string, nextRetryAt
 	}
 
 	if limit > 0 {
-		orderQuery += " LIMIT $3"
+		orderQuery += fmt.Sprintf(" LIMIT $%d", len(args)+1)
 		args = append(args, limit)
 	}
 
-	var orders []models.Order
+	return s.queryOrders(ctx, orderQuery, args...)
+}
+
+// GetDueScheduled returns up to limit scheduled orders whose release time has
+// come, or all of them if limit is 0.
+func (s *OrderStore) GetDueScheduled(ctx context.Context, limit int32) ([]models.Order, error) {
+	query := `
+		SELECT id, deliver_at
+		FROM orders
+		WHERE status = $1 AND release_at <= NOW()
+		ORDER BY release_at ASC
+		LIMIT NULLIF($2, 0)
+	`
 
-	orderRows, err := s.db.Query(ctx, orderQuery, args...)
+	rows, err := s.db.Query(ctx, query, models.OrderStatusScheduled, limit)
 	if err != nil {
-		return orders, nil
+		return nil, err
 	}
 
-	for orderRows.Next() {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Order, error) {
 		var order models.Order
+		err := row.Scan(&order.ID, &order.DeliverAt)
+		return order, err
+	})
+}
 
-		err := orderRows.Scan(
-			&order.ID,
-			&order.RestaurantID,
-			&order.UserID,
-			&order.TotalPrice,
-			&order.Status,
-			&order.CourierID,
-			&order.RetryCount,
-			&order.MaxRetryCount,
-			&order.NextRetryAt,
-			&order.CreatedAt,
-			&order.UpdatedAt,
-		)
-
-		if err != nil {
-			return orders, err
-		}
-
-		itemsQuery := `
-			SELECT 
-			id, order_id, menu_item_id, quantity, price
-			FROM
-			orders_items
-			WHERE
-			order_id = $1
-		`
-
-		itemRows, err := s.db.Query(ctx, itemsQuery, order.ID)
-		if err != nil {
-			return orders, err
-		}
-
-		for itemRows.Next() {
-			var item models.OrderItem
-			if err := itemRows.Scan(&item.ID, &item.OrderID, &item.MenuItemID, &item.Quantity, &item.Price); err != nil {
-				return orders, err
-			}
-
-			order.Items = append(order.Items, item)
-		}
+// GetExpiredUnpaid returns up to limit pending or payment_failed orders
+// unchanged for at least ttl, or all of them if limit is 0.
+func (s *OrderStore) GetExpiredUnpaid(ctx context.Context, ttl time.Duration, limit int32) ([]models.Order, error) {
+	query := `
+		SELECT id, status, updated_at
+		FROM orders
+		WHERE status = ANY($1) AND updated_at <= $2
+		ORDER BY updated_at ASC
+		LIMIT NULLIF($3, 0)
+	`
 
-		orders = append(orders, order)
+	rows, err := s.db.Query(ctx, query, models.PreviousStatuses(models.OrderStatusExpired), time.Now().Add(-ttl), limit)
+	if err != nil {
+		return nil, err
 	}
 
-	return orders, nil
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Order, error) {
+		var order models.Order
+		err := row.Scan(&order.ID, &order.Status, &order.UpdatedAt)
+		return order, err
+	})
 }
 
-func (s *OrderStore) GetTotalPrice(ctx context.Context, orderID string) (float64, error) {
+// GetForPayment returns the owner, total price and status of the order, which
+// payments-service checks payment requests against.
+func (s *OrderStore) GetForPayment(ctx context.Context, orderID string) (models.Order, error) {
 	query := `
-		SELECT total_price
+		SELECT id, user_id, total_price, currency, status
 		FROM orders
 		WHERE id = $1
 	`
 
-	var totalPrice float64
-	err := s.db.QueryRow(ctx, query, orderID).Scan(&totalPrice)
+	var order models.Order
+	err := s.db.QueryRow(ctx, query, orderID).Scan(&order.ID, &order.UserID, &order.TotalPrice.Amount, &order.TotalPrice.Currency, &order.Status)
+
+	return order, err
+}
+
+// Create stores the order with its items and initial status. The promotion
+// of a discounted order is redeemed in the same transaction, failing with
+// ErrPromotionExhausted if its usage limits are reached. The events built
+// from the stored order are written to the outbox in the same transaction.
+func (s *OrderStore) Create(ctx context.Context, order *models.Order, source, actor string, events func(order *models.Order) ([]models.OutboxEvent, error)) error {
+	return s.create(ctx, order, nil, source, actor, events)
+}
 
-	return totalPrice, err
+// CreateFromCart stores the order the way Create does and deletes the cart it
+// was placed from in the same transaction. It fails with ErrCartChanged if the
+// cart is gone or changed since it was read, e.g. by a concurrent checkout, so
+// a cart only ever becomes one order.
+func (s *OrderStore) CreateFromCart(ctx context.Context, order *models.Order, cart models.Cart, source, actor string, events func(order *models.Order) ([]models.OutboxEvent, error)) error {
+	return s.create(ctx, order, &cart, source, actor, events)
 }
 
-func (s *OrderStore) Create(ctx context.Context, order *models.Order) error {
+func (s *OrderStore) create(ctx context.Context, order *models.Order, cart *models.Cart, source, actor string, events func(order *models.Order) ([]models.OutboxEvent, error)) error {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback(ctx)
 
+	// the row lock makes a concurrent checkout of the cart wait for this one
+	// and then find nothing to delete
+	if cart != nil {
+		tag, err := tx.Exec(ctx, "DELETE FROM carts WHERE id = $1 AND updated_at = $2", cart.ID, cart.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrCartChanged
+		}
+	}
+
+	// orders are charged right away unless they are scheduled
+	if order.Status == models.OrderStatusPending {
+		order.PaymentAttempts = 1
+	}
+
 	orderQuery := `
-		INSERT INTO orders (user_id, restaurant_id, total_price, status)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO orders (user_id, restaurant_id, subtotal, delivery_fee, service_fee, small_order_fee, promotion_id, promo_code, discount_type, discount, tax, total_price, currency, status, payment_attempts, delivery_address, deliver_at, release_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		RETURNING id, created_at, updated_at`
 
-	err = tx.QueryRow(ctx, orderQuery, order.UserID, order.RestaurantID, order.TotalPrice, order.Status).
+	var promotionID, promoCode, discountType *string
+	var discount int64
+	if order.Discount != nil {
+		promotionID = &order.Discount.PromotionID
+		promoCode = &order.Discount.Code
+		discountType = &order.Discount.Type
+		discount = order.Discount.Amount.Amount
+	}
+
+	err = tx.QueryRow(ctx, orderQuery, order.UserID, order.RestaurantID, order.Subtotal.Amount, order.DeliveryFee.Amount, order.ServiceFee.Amount, order.SmallOrderFee.Amount,
+		promotionID, promoCode, discountType, discount, order.Tax.Amount, order.TotalPrice.Amount, order.TotalPrice.Currency, order.Status, order.PaymentAttempts, order.DeliveryAddress, order.DeliverAt, order.ReleaseAt).
 		Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
 	if err != nil {
 		return err
 	}
 
+	if order.Discount != nil {
+		if err := redeemPromotion(ctx, tx, order); err != nil {
+			return err
+		}
+	}
+
 	itemRows := [][]any{}
 	for _, item := range order.Items {
-		itemRows = append(itemRows, []any{order.ID, item.MenuItemID, item.Quantity, item.Price})
+		options := item.Options
+		if options == nil {
+			options = []string{}
+		}
+		itemRows = append(itemRows, []any{order.ID, item.MenuItemID, item.Name, item.Description, options, item.Quantity, item.Price.Amount, item.Price.Currency})
 	}
 
 	_, err = tx.CopyFrom(
 		ctx,
 		pgx.Identifier{"orders_items"},
-		[]string{"order_id", "menu_item_id", "quantity", "price"},
+		[]string{"order_id", "menu_item_id", "name", "description", "options", "quantity", "price", "currency"},
 		pgx.CopyFromRows(itemRows),
 	)
 
@@ -293,9 +459,78 @@ func (s *OrderStore) Create(ctx context.Context, order *models.Order) error {
 		return err
 	}
 
+	if err := insertStatusChange(ctx, tx, order.ID, nil, order.Status, source, actor); err != nil {
+		return err
+	}
+
+	outboxEvents, err := events(order)
+	if err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return err
+	}
+
 	return tx.Commit(ctx)
 }
 
+// RequestPayment numbers a new payment attempt of an order still waiting for
+// payment, failing with ErrOrderNotPayable otherwise. The events built from
+// the order go to the outbox in the same transaction.
+func (s *OrderStore) RequestPayment(ctx context.Context, orderID string, events func(order models.Order) ([]models.OutboxEvent, error)) (models.Order, error) {
+	var order models.Order
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return order, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, user_id, total_price, currency, status
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, orderID).Scan(&order.ID, &order.UserID, &order.TotalPrice.Amount, &order.TotalPrice.Currency, &order.Status)
+	if err != nil {
+		return order, err
+	}
+
+	// expired and cancelled orders must not be charged anymore
+	if !slices.Contains(models.PreviousStatuses(models.OrderStatusPaid), order.Status) {
+		return order, ErrOrderNotPayable
+	}
+
+	order.PaymentAttempts, err = nextPaymentAttempt(ctx, tx, orderID)
+	if err != nil {
+		return order, err
+	}
+
+	outboxEvents, err := events(order)
+	if err != nil {
+		return order, err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return order, err
+	}
+
+	return order, tx.Commit(ctx)
+}
+
+// nextPaymentAttempt counts a new payment attempt of the order and returns
+// its number. The attempt restarts the unpaid ttl of the order.
+func nextPaymentAttempt(ctx context.Context, tx pgx.Tx, orderID string) (int, error) {
+	var attempt int
+	err := tx.QueryRow(ctx, "UPDATE orders SET payment_attempts = payment_attempts + 1, updated_at = NOW() WHERE id = $1 RETURNING payment_attempts", orderID).
+		Scan(&attempt)
+
+	return attempt, err
+}
+
 func (s *OrderStore) IncreaseRetryCounter(ctx context.Context, orderID string) (retriesExceeded bool, err error) {
 	query := `
 		UPDATE orders
@@ -317,40 +552,433 @@ func (s *OrderStore) IncreaseRetryCounter(ctx context.Context, orderID string) (
 	return false, nil
 }
 
-func (s *OrderStore) UpdateStatus(ctx context.Context, orderID string, status string) error {
+// UpdateStatus moves the order to status if the state machine allows it and
+// records the change in the order history. source is the event or API that
+// caused the change, actor is who triggered it. events are written to the
+// outbox only if the change is applied.
+func (s *OrderStore) UpdateStatus(ctx context.Context, orderID, status, source, actor string, events ...models.OutboxEvent) error {
+	return s.transition(ctx, orderID, status, source, actor, "", "", nil, events)
+}
+
+// Pay moves the order to paid with events. An order cancelled or expired while
+// payments-service was still charging it cannot be paid any more and was not
+// refunded when it left pending, so what was charged is asked back instead
+// and refundEvents go to the outbox in the same transaction. The refund is
+// nil when the order was paid.
+func (s *OrderStore) Pay(ctx context.Context, orderID, source, actor string, events []models.OutboxEvent, refundEvents func(order models.Order, refund models.Refund) ([]models.OutboxEvent, error)) (*models.Refund, error) {
+	err := s.transition(ctx, orderID, models.OrderStatusPaid, source, actor, "", "", nil, events)
+	if !errors.Is(err, ErrInvalidStatusTransition) {
+		return nil, err
+	}
+
+	refund, refundErr := s.refundLatePayment(ctx, orderID, actor, refundEvents)
+	if refundErr != nil || refund == nil {
+		return nil, errors.Join(err, refundErr)
+	}
+
+	return refund, nil
+}
+
+// refundLatePayment asks for the whole total of a cancelled or expired order
+// that was never paid back. It returns nil when the order is in another
+// status, was paid before, or its refund was already requested, so a
+// redelivered payment.succeeded does not refund twice.
+func (s *OrderStore) refundLatePayment(ctx context.Context, orderID, actor string, events func(order models.Order, refund models.Refund) ([]models.OutboxEvent, error)) (*models.Refund, error) {
 	query := `
-		UPDATE orders
-		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		SELECT id, user_id, status, total_price, currency,
+			EXISTS (SELECT 1 FROM order_status_history WHERE order_id = $1 AND to_status = $2)
+		FROM orders
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var order models.Order
+	var paid bool
+	err = tx.QueryRow(ctx, query, orderID, models.OrderStatusPaid).
+		Scan(&order.ID, &order.UserID, &order.Status, &order.TotalPrice.Amount, &order.TotalPrice.Currency, &paid)
+	if err != nil {
+		return nil, err
+	}
+
+	if paid || (order.Status != models.OrderStatusCancelled && order.Status != models.OrderStatusExpired) {
+		return nil, nil
+	}
+
+	refund, err := requestRemainingRefund(ctx, tx, order, "paid after order "+order.Status, actor)
+	if err != nil || refund == nil {
+		return nil, err
+	}
+
+	outboxEvents, err := events(order, *refund)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return nil, err
+	}
+
+	return refund, tx.Commit(ctx)
+}
+
+// Accept moves a paid order to accepted, storing when the restaurant expects
+// it to be ready and when a courier has to be requested for it.
+func (s *OrderStore) Accept(ctx context.Context, orderID string, readyAt, courierDispatchAt time.Time, source, actor string, events ...models.OutboxEvent) error {
+	set := ", estimated_ready_at = $4, courier_dispatch_at = $5"
+
+	return s.transition(ctx, orderID, models.OrderStatusAccepted, source, actor, set, "", []any{readyAt, courierDispatchAt}, events)
+}
+
+// PickUp moves the order to picked_up if courierID is the courier assigned
+// to it.
+func (s *OrderStore) PickUp(ctx context.Context, orderID, courierID, source, actor string, events ...models.OutboxEvent) error {
+	where := " AND prev.courier_id::text = $4"
+
+	return s.transition(ctx, orderID, models.OrderStatusPickedUp, source, actor, "", where, []any{courierID}, events)
+}
+
+// transition is the compare-and-set status update behind UpdateStatus,
+// Accept and PickUp. set holds extra assignments and where extra conditions
+// on the previous row, prev; their placeholders start at $4 and are bound to
+// args. Only orders with an assigned courier can be picked up.
+func (s *OrderStore) transition(ctx context.Context, orderID, status, source, actor, set, where string, args []any, events []models.OutboxEvent) error {
+	query := `
+		UPDATE orders AS o
+		SET status = $1, updated_at = NOW()` + set + `
+		FROM (SELECT id, status, courier_id FROM orders WHERE id = $2 FOR UPDATE) AS prev
+		WHERE o.id = prev.id AND prev.status = ANY($3)
+			AND ($1 <> '` + models.OrderStatusPickedUp + `' OR prev.courier_id IS NOT NULL)` + where + `
+		RETURNING prev.status
+	`
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var prevStatus string
+
+	args = append([]any{status, orderID, models.PreviousStatuses(status)}, args...)
+
+	err = tx.QueryRow(ctx, query, args...).Scan(&prevStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.rejectTransition(ctx, orderID, status)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := insertStatusChange(ctx, tx, orderID, &prevStatus, status, source, actor); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AssignCourier records the courier taking the order. Orders the restaurant
+// is working on keep their kitchen status; orders waiting for a courier since
+// before restaurants confirmed orders move to awaiting_pickup.
+func (s *OrderStore) AssignCourier(ctx context.Context, orderID, courierID, source, actor string, events ...models.OutboxEvent) error {
+	query := `
+		UPDATE orders AS o
+		SET status = CASE WHEN prev.status = ANY($1) THEN prev.status ELSE $2 END, courier_id = $3, updated_at = NOW()
+		FROM (SELECT id, status FROM orders WHERE id = $4 FOR UPDATE) AS prev
+		WHERE o.id = prev.id AND prev.status = ANY($5)
+		RETURNING prev.status, o.status
 	`
 
-	result, err := s.db.Exec(ctx, query, status, orderID)
+	status := models.OrderStatusAwaitingPickup
+	allowed := append(slices.Clone(models.KitchenStatuses), models.PreviousStatuses(status)...)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var prevStatus, newStatus string
+
+	err = tx.QueryRow(ctx, query, models.KitchenStatuses, status, courierID, orderID, allowed).Scan(&prevStatus, &newStatus)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.rejectTransition(ctx, orderID, status)
+	}
 	if err != nil {
 		return err
 	}
 
-	if result.RowsAffected() == 0 {
-		slog.Warn("order is not found for status update", "order_id", orderID)
+	if err := insertStatusChange(ctx, tx, orderID, &prevStatus, newStatus, source, actor); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, events); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DispatchCouriers marks up to limit accepted orders whose courier dispatch
+// time has come as requested and writes the events built for each of them to
+// the outbox in the same transaction. The orders passed to events carry their
+// id, restaurant and delivery address. It returns how many orders were dispatched.
+func (s *OrderStore) DispatchCouriers(ctx context.Context, limit int, events func(order models.Order) ([]models.OutboxEvent, error)) (int, error) {
+	query := `
+		UPDATE orders
+		SET courier_requested_at = NOW(), updated_at = NOW()
+		WHERE id IN (
+			SELECT id
+			FROM orders
+			WHERE status = ANY($1) AND courier_id IS NULL AND courier_requested_at IS NULL AND courier_dispatch_at <= NOW()
+			ORDER BY courier_dispatch_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, restaurant_id, delivery_address
+	`
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, models.KitchenStatuses, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Order, error) {
+		var order models.Order
+		err := row.Scan(&order.ID, &order.RestaurantID, &order.DeliveryAddress)
+		return order, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var outboxEvents []models.OutboxEvent
+	for _, order := range orders {
+		orderEvents, err := events(order)
+		if err != nil {
+			return 0, err
+		}
+		outboxEvents = append(outboxEvents, orderEvents...)
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return 0, err
 	}
 
-	return nil
+	return len(orders), tx.Commit(ctx)
 }
 
-func (s *OrderStore) AssignCourier(ctx context.Context, orderID, courierID string) error {
+// RescheduleCourierDispatch requests a courier for an accepted order again
+// after delay. It reports false if the order is not waiting for a courier
+// in a kitchen status.
+func (s *OrderStore) RescheduleCourierDispatch(ctx context.Context, orderID string, delay time.Duration) (bool, error) {
 	query := `
 		UPDATE orders
-		SET status = 'awaiting_pickup', courier_id = $1, updated_at = NOW()
-		WHERE id = $2
+		SET courier_requested_at = NULL, courier_dispatch_at = NOW() + make_interval(secs => $2), updated_at = NOW()
+		WHERE id = $1 AND status = ANY($3) AND courier_id IS NULL
+	`
+
+	tag, err := s.db.Exec(ctx, query, orderID, delay.Seconds(), models.KitchenStatuses)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Cancel cancels the order if its status still allows it. The events built
+// from the cancelled order, its previous status and the refund of a paid
+// order go to the outbox in the same transaction.
+func (s *OrderStore) Cancel(ctx context.Context, orderID, source, actor string, events func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error)) (order models.Order, prevStatus string, err error) {
+	return s.changeStatus(ctx, orderID, models.OrderStatusCancelled, source, actor, "", nil, events)
+}
+
+// Reject marks a paid order as rejected by the restaurant, building its
+// outbox events the same way Cancel does.
+func (s *OrderStore) Reject(ctx context.Context, orderID, source, actor string, events func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error)) (order models.Order, prevStatus string, err error) {
+	return s.changeStatus(ctx, orderID, models.OrderStatusRejected, source, actor, "", nil, events)
+}
+
+// Release moves a scheduled order to pending, building its outbox events the
+// same way Cancel does.
+func (s *OrderStore) Release(ctx context.Context, orderID, source, actor string, events func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error)) (order models.Order, prevStatus string, err error) {
+	return s.changeStatus(ctx, orderID, models.OrderStatusPending, source, actor, "", nil, events)
+}
+
+// Expire marks an order left unpaid for ttl as expired, building its outbox
+// events the same way Cancel does. The ttl is checked again under the row
+// lock, so a payment attempt made after the order was listed keeps it alive.
+func (s *OrderStore) Expire(ctx context.Context, orderID string, ttl time.Duration, source, actor string, events func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error)) (order models.Order, prevStatus string, err error) {
+	where := " AND prev.updated_at <= NOW() - make_interval(secs => $4)"
+	return s.changeStatus(ctx, orderID, models.OrderStatusExpired, source, actor, where, []any{ttl.Seconds()}, events)
+}
+
+// changeStatus moves the order to status and returns the updated order with
+// the status it had before. Orders that will never be delivered give back
+// their promotion redemption, and paid ones get a refund of what is left of
+// their payment. where and args narrow down the previous row the same way
+// they do in transition.
+func (s *OrderStore) changeStatus(ctx context.Context, orderID, status, source, actor, where string, args []any, events func(order models.Order, prevStatus string, refund *models.Refund) ([]models.OutboxEvent, error)) (order models.Order, prevStatus string, err error) {
+	query := `
+		UPDATE orders AS o
+		SET status = $1, updated_at = NOW()
+		FROM (SELECT id, status, updated_at FROM orders WHERE id = $2 FOR UPDATE) AS prev
+		WHERE o.id = prev.id AND prev.status = ANY($3)` + where + `
+		RETURNING prev.status, o.id, o.restaurant_id, o.user_id, o.total_price, o.currency, o.status, o.courier_id, o.updated_at
+	`
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return order, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	args = append([]any{status, orderID, models.PreviousStatuses(status)}, args...)
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&prevStatus,
+		&order.ID,
+		&order.RestaurantID,
+		&order.UserID,
+		&order.TotalPrice.Amount,
+		&order.TotalPrice.Currency,
+		&order.Status,
+		&order.CourierID,
+		&order.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return order, "", s.rejectTransition(ctx, orderID, status)
+	}
+	if err != nil {
+		return order, "", err
+	}
+
+	if err := insertStatusChange(ctx, tx, orderID, &prevStatus, status, source, actor); err != nil {
+		return order, "", err
+	}
+
+	// released orders are charged right away
+	if status == models.OrderStatusPending {
+		order.PaymentAttempts, err = nextPaymentAttempt(ctx, tx, orderID)
+		if err != nil {
+			return order, "", err
+		}
+	}
+
+	var refund *models.Refund
+	if models.IsUnfulfilledStatus(status) {
+		if err := releasePromotion(ctx, tx, orderID); err != nil {
+			return order, "", err
+		}
+
+		if slices.Contains(models.RefundableStatuses, prevStatus) {
+			refund, err = requestRemainingRefund(ctx, tx, order, "order "+status, actor)
+			if err != nil {
+				return order, "", err
+			}
+		}
+	}
+
+	outboxEvents, err := events(order, prevStatus, refund)
+	if err != nil {
+		return order, "", err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return order, "", err
+	}
+
+	return order, prevStatus, tx.Commit(ctx)
+}
+
+func (s *OrderStore) GetStatusHistory(ctx context.Context, orderID string) ([]models.OrderStatusChange, error) {
+	query := `
+		SELECT id, order_id, from_status, to_status, source, actor, created_at
+		FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at ASC
+	`
+
+	var history []models.OrderStatusChange
+
+	rows, err := s.db.Query(ctx, query, orderID)
+	if err != nil {
+		return history, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var change models.OrderStatusChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.OrderID,
+			&change.FromStatus,
+			&change.ToStatus,
+			&change.Source,
+			&change.Actor,
+			&change.CreatedAt,
+		); err != nil {
+			return history, err
+		}
+
+		history = append(history, change)
+	}
+
+	return history, rows.Err()
+}
+
+func insertStatusChange(ctx context.Context, tx pgx.Tx, orderID string, fromStatus *string, toStatus, source, actor string) error {
+	query := `
+		INSERT INTO order_status_history (order_id, from_status, to_status, source, actor)
+		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	result, err := s.db.Exec(ctx, query, courierID, orderID)
+	_, err := tx.Exec(ctx, query, orderID, fromStatus, toStatus, source, actor)
+
+	return err
+}
+
+// RejectedTransitions returns how many status transitions were refused since start, keyed by "from->to".
+func (s *OrderStore) RejectedTransitions() map[string]int64 {
+	return s.rejected.snapshot()
+}
+
+// rejectTransition is called when a compare-and-set status update matched no row:
+// either the order does not exist or its current status does not allow the change.
+func (s *OrderStore) rejectTransition(ctx context.Context, orderID, status string) error {
+	current, err := s.GetStatus(ctx, orderID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			slog.Warn("order is not found for status update", "order_id", orderID)
+		}
 		return err
 	}
 
-	if result.RowsAffected() == 0 {
-		return errors.New("Error assigning courier: cannot found order with id: " + orderID)
+	count := s.rejected.add(current, status)
+	slog.Warn("order status transition rejected", "order_id", orderID, "from", current, "to", status, "rejected_total", count)
+
+	// the state machine allows the move, so the courier did not match
+	if status == models.OrderStatusPickedUp && slices.Contains(models.PreviousStatuses(status), current) {
+		return fmt.Errorf("%w: %s -> %s: %w", ErrInvalidStatusTransition, current, status, ErrCourierNotAssigned)
+	}
+	// or the order was paid for again after it was listed as expired
+	if status == models.OrderStatusExpired && slices.Contains(models.PreviousStatuses(status), current) {
+		return fmt.Errorf("%w: %s -> %s: %w", ErrInvalidStatusTransition, current, status, ErrOrderNotExpired)
 	}
 
-	return nil
+	return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, current, status)
 }
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const maxOutboxBackoff = 5 * time.Minute
+
+type OutboxStore struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxStore(db *pgxpool.Pool) *OutboxStore {
+	return &OutboxStore{db: db}
+}
+
+// Relay publishes up to limit due outbox events in one call and marks the
+// published ones as sent. publish returns the error of every event by index,
+// or nil when all of them were published. Rows are locked with SKIP LOCKED,
+// so several relays can run side by side. Only the oldest unsent event of a
+// key is picked, so a failed event, rescheduled with exponential backoff,
+// holds back the later events of its order until it goes out.
+func (s *OutboxStore) Relay(ctx context.Context, limit int, publish func(events []models.OutboxEvent) []error) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, topic, key, payload, attempts, created_at
+		FROM outbox
+		WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox o2
+				WHERE o2.key = outbox.key AND o2.sent_at IS NULL AND o2.id < outbox.id
+			)
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxEvent, error) {
+		var event models.OutboxEvent
+		err := row.Scan(&event.ID, &event.Topic, &event.Key, &event.Payload, &event.Attempts, &event.CreatedAt)
+		return event, err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	errs := publish(events)
+	sentIDs := make([]int64, 0, len(events))
+
+	for i, event := range events {
+		if errs == nil || errs[i] == nil {
+			sentIDs = append(sentIDs, event.ID)
+			continue
+		}
+
+		backoff := min(time.Duration(1<<min(event.Attempts, 16))*time.Second, maxOutboxBackoff)
+		slog.Warn("failed to publish outbox event", "id", event.ID, "topic", event.Topic, "attempts", event.Attempts+1, "retry_in", backoff, "error", errs[i])
+
+		_, err := tx.Exec(ctx, `
+			UPDATE outbox
+			SET attempts = attempts + 1, last_error = $1, next_attempt_at = NOW() + make_interval(secs => $2)
+			WHERE id = $3
+		`, errs[i].Error(), backoff.Seconds(), event.ID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "UPDATE outbox SET sent_at = NOW() WHERE id = ANY($1)", sentIDs); err != nil {
+		return 0, err
+	}
+
+	return len(sentIDs), tx.Commit(ctx)
+}
+
+// DeleteSent removes events sent more than retention ago.
+func (s *OutboxStore) DeleteSent(ctx context.Context, retention time.Duration) (int64, error) {
+	query := `
+		DELETE FROM outbox
+		WHERE sent_at < NOW() - make_interval(secs => $1)
+	`
+
+	result, err := s.db.Exec(ctx, query, retention.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func insertOutboxEvents(ctx context.Context, tx pgx.Tx, events []models.OutboxEvent) error {
+	query := `
+		INSERT INTO outbox (topic, key, payload)
+		VALUES ($1, $2, $3)
+	`
+
+	for _, event := range events {
+		if _, err := tx.Exec(ctx, query, event.Topic, event.Key, event.Payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPromotionCodeTaken = errors.New("promo code already exists")
+	ErrPromotionExhausted = errors.New("promo code usage limit reached")
+)
+
+type PromotionStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPromotionStore(db *pgxpool.Pool) *PromotionStore {
+	return &PromotionStore{db: db}
+}
+
+// promotionColumns is the column list scanPromotion expects.
+const promotionColumns = `id, code, type, percent_off, amount_off, min_order_value, currency, restaurant_id, starts_at, ends_at, max_redemptions, max_redemptions_per_user, redemptions_count, is_active, created_at, updated_at`
+
+func scanPromotion(row pgx.Row) (models.Promotion, error) {
+	var promotion models.Promotion
+	var percentOff *int
+	var amountOff, minOrderValue *int64
+	var currency *string
+
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Code,
+		&promotion.Type,
+		&percentOff,
+		&amountOff,
+		&minOrderValue,
+		&currency,
+		&promotion.RestaurantID,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.MaxRedemptions,
+		&promotion.MaxRedemptionsPerUser,
+		&promotion.RedemptionsCount,
+		&promotion.IsActive,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return promotion, err
+	}
+
+	if percentOff != nil {
+		promotion.PercentOff = *percentOff
+	}
+	if amountOff != nil {
+		amount := money.New(*amountOff, *currency)
+		promotion.AmountOff = &amount
+	}
+	if minOrderValue != nil {
+		value := money.New(*minOrderValue, *currency)
+		promotion.MinOrderValue = &value
+	}
+
+	return promotion, nil
+}
+
+// NormalizeCode makes promo codes case insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (s *PromotionStore) Create(ctx context.Context, promotion *models.Promotion) error {
+	query := `
+		INSERT INTO promotions (code, type, percent_off, amount_off, min_order_value, currency, restaurant_id, starts_at, ends_at, max_redemptions, max_redemptions_per_user)
+		VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (code) DO NOTHING
+		RETURNING id, is_active, created_at, updated_at
+	`
+
+	var amountOff, minOrderValue *int64
+	var currency *string
+	if promotion.AmountOff != nil {
+		amountOff = &promotion.AmountOff.Amount
+		currency = &promotion.AmountOff.Currency
+	}
+	if promotion.MinOrderValue != nil {
+		minOrderValue = &promotion.MinOrderValue.Amount
+		currency = &promotion.MinOrderValue.Currency
+	}
+
+	promotion.Code = NormalizeCode(promotion.Code)
+
+	err := s.db.QueryRow(ctx, query,
+		promotion.Code,
+		promotion.Type,
+		promotion.PercentOff,
+		amountOff,
+		minOrderValue,
+		currency,
+		promotion.RestaurantID,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.MaxRedemptions,
+		promotion.MaxRedemptionsPerUser,
+	).Scan(&promotion.ID, &promotion.IsActive, &promotion.CreatedAt, &promotion.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPromotionCodeTaken
+	}
+
+	return err
+}
+
+func (s *PromotionStore) GetAll(ctx context.Context) ([]models.Promotion, error) {
+	query := "SELECT " + promotionColumns + " FROM promotions ORDER BY created_at DESC"
+
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Promotion, error) {
+		return scanPromotion(row)
+	})
+}
+
+func (s *PromotionStore) GetByCode(ctx context.Context, code string) (models.Promotion, error) {
+	query := "SELECT " + promotionColumns + " FROM promotions WHERE code = $1"
+
+	return scanPromotion(s.db.QueryRow(ctx, query, NormalizeCode(code)))
+}
+
+// SetActive enables or disables a promotion. Disabled codes can no longer be
+// redeemed but stay on the orders that used them.
+func (s *PromotionStore) SetActive(ctx context.Context, id string, active bool) (models.Promotion, error) {
+	query := `
+		UPDATE promotions
+		SET is_active = $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + promotionColumns
+
+	return scanPromotion(s.db.QueryRow(ctx, query, id, active))
+}
+
+// CountUserRedemptions returns how many orders of the user used the promotion.
+func (s *PromotionStore) CountUserRedemptions(ctx context.Context, promotionID, userID string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM promotion_redemptions
+		WHERE promotion_id = $1 AND user_id = $2
+	`
+
+	var count int
+	err := s.db.QueryRow(ctx, query, promotionID, userID).Scan(&count)
+
+	return count, err
+}
+
+// redeemPromotion records the use of the order's promotion. Incrementing the
+// counter locks the promotion row, so concurrent redemptions of one code are
+// serialized and neither the global nor the per user limit can be exceeded.
+func redeemPromotion(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+	counterQuery := `
+		UPDATE promotions
+		SET redemptions_count = redemptions_count + 1, updated_at = NOW()
+		WHERE id = $1 AND is_active AND (max_redemptions IS NULL OR redemptions_count < max_redemptions)
+		RETURNING max_redemptions_per_user
+	`
+
+	var maxPerUser *int
+	err := tx.QueryRow(ctx, counterQuery, order.Discount.PromotionID).Scan(&maxPerUser)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPromotionExhausted
+	}
+	if err != nil {
+		return err
+	}
+
+	if maxPerUser != nil {
+		var used int
+		err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2", order.Discount.PromotionID, order.UserID).
+			Scan(&used)
+		if err != nil {
+			return err
+		}
+		if used >= *maxPerUser {
+			return ErrPromotionExhausted
+		}
+	}
+
+	redemptionQuery := `
+		INSERT INTO promotion_redemptions (promotion_id, order_id, user_id, discount, currency)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err = tx.Exec(ctx, redemptionQuery, order.Discount.PromotionID, order.ID, order.UserID, order.Discount.Amount.Amount, order.Discount.Amount.Currency)
+
+	return err
+}
+
+// releasePromotion gives the redemption of an order that will never be
+// delivered back to the promotion and its user.
+func releasePromotion(ctx context.Context, tx pgx.Tx, orderID string) error {
+	query := `
+		WITH released AS (
+			DELETE FROM promotion_redemptions
+			WHERE order_id = $1
+			RETURNING promotion_id
+		)
+		UPDATE promotions
+		SET redemptions_count = redemptions_count - 1, updated_at = NOW()
+		WHERE id IN (SELECT promotion_id FROM released)
+	`
+
+	_, err := tx.Exec(ctx, query, orderID)
+
+	return err
+}
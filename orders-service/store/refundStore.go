@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrOrderNotRefundable  = errors.New("order cannot be refunded")
+	ErrOrderItemNotFound   = errors.New("order item not found")
+	ErrRefundExceedsOrder  = errors.New("refund exceeds what is left to refund")
+	ErrRefundNotInProgress = errors.New("refund is not waiting for a result")
+)
+
+type RefundStore struct {
+	db *pgxpool.Pool
+}
+
+func NewRefundStore(db *pgxpool.Pool) *RefundStore {
+	return &RefundStore{db: db}
+}
+
+// refundColumns is the column list scanRefund expects.
+const refundColumns = `id, order_id, order_item_id, quantity, amount, currency, reason, status, failure_reason, requested_by, created_at, updated_at`
+
+func scanRefund(row pgx.Row) (models.Refund, error) {
+	var refund models.Refund
+
+	err := row.Scan(
+		&refund.ID,
+		&refund.OrderID,
+		&refund.OrderItemID,
+		&refund.Quantity,
+		&refund.Amount.Amount,
+		&refund.Amount.Currency,
+		&refund.Reason,
+		&refund.Status,
+		&refund.FailureReason,
+		&refund.RequestedBy,
+		&refund.CreatedAt,
+		&refund.UpdatedAt,
+	)
+
+	return refund, err
+}
+
+func (s *RefundStore) GetByOrderID(ctx context.Context, orderID string) ([]models.Refund, error) {
+	query := "SELECT " + refundColumns + " FROM refunds WHERE order_id = $1 ORDER BY created_at ASC"
+
+	rows, err := s.db.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Refund, error) {
+		return scanRefund(row)
+	})
+}
+
+// RequestItemRefund asks for quantity units of an order item back, for example
+// when they were missing from the delivery. The amount is the item price,
+// capped by what is left of the order total once the discount and earlier
+// refunds are taken into account.
+func (s *RefundStore) RequestItemRefund(ctx context.Context, orderID, orderItemID string, quantity int, reason, actor string, events func(order models.Order, refund models.Refund) ([]models.OutboxEvent, error)) (models.Refund, error) {
+	var refund models.Refund
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return refund, err
+	}
+	defer tx.Rollback(ctx)
+
+	var order models.Order
+	err = tx.QueryRow(ctx, "SELECT id, user_id, status, total_price, currency FROM orders WHERE id = $1 FOR UPDATE", orderID).
+		Scan(&order.ID, &order.UserID, &order.Status, &order.TotalPrice.Amount, &order.TotalPrice.Currency)
+	if err != nil {
+		return refund, err
+	}
+
+	if !slices.Contains(models.RefundableStatuses, order.Status) {
+		return refund, ErrOrderNotRefundable
+	}
+
+	var price money.Money
+	var itemQuantity int
+	err = tx.QueryRow(ctx, "SELECT price, currency, quantity FROM orders_items WHERE id = $1 AND order_id = $2", orderItemID, orderID).
+		Scan(&price.Amount, &price.Currency, &itemQuantity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return refund, ErrOrderItemNotFound
+	}
+	if err != nil {
+		return refund, err
+	}
+
+	var refundedQuantity int
+	err = tx.QueryRow(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM refunds WHERE order_item_id = $1 AND status <> $2", orderItemID, models.RefundStatusFailed).
+		Scan(&refundedQuantity)
+	if err != nil {
+		return refund, err
+	}
+
+	if refundedQuantity+quantity > itemQuantity {
+		return refund, ErrRefundExceedsOrder
+	}
+
+	remaining, err := remainingRefundAmount(ctx, tx, order)
+	if err != nil {
+		return refund, err
+	}
+
+	amount := min(price.Multiply(int64(quantity)).Amount, remaining)
+	if amount <= 0 {
+		return refund, ErrRefundExceedsOrder
+	}
+
+	refund = models.Refund{
+		OrderID:     orderID,
+		OrderItemID: &orderItemID,
+		Quantity:    &quantity,
+		Amount:      money.New(amount, order.TotalPrice.Currency),
+		Reason:      reason,
+		RequestedBy: actor,
+	}
+	if err := insertRefund(ctx, tx, &refund); err != nil {
+		return refund, err
+	}
+
+	outboxEvents, err := events(order, refund)
+	if err != nil {
+		return refund, err
+	}
+
+	if err := insertOutboxEvents(ctx, tx, outboxEvents); err != nil {
+		return refund, err
+	}
+
+	return refund, tx.Commit(ctx)
+}
+
+// Complete records that payments-service gave the refund back. Once the
+// whole order total is refunded the order moves to refunded.
+func (s *RefundStore) Complete(ctx context.Context, refundID, source, actor string) (models.Refund, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return models.Refund{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	refund, err := finishRefund(ctx, tx, refundID, models.RefundStatusSucceeded, nil)
+	if err != nil {
+		return refund, err
+	}
+
+	query := `
+		UPDATE orders
+		SET refunded_amount = refunded_amount + $2, updated_at = NOW()
+		WHERE id = $1
+		RETURNING status, total_price, refunded_amount
+	`
+
+	var status string
+	var totalPrice, refundedAmount int64
+	err = tx.QueryRow(ctx, query, refund.OrderID, refund.Amount.Amount).Scan(&status, &totalPrice, &refundedAmount)
+	if err != nil {
+		return refund, err
+	}
+
+	if refundedAmount >= totalPrice && slices.Contains(models.PreviousStatuses(models.OrderStatusRefunded), status) {
+		_, err := tx.Exec(ctx, "UPDATE orders SET status = $2 WHERE id = $1", refund.OrderID, models.OrderStatusRefunded)
+		if err != nil {
+			return refund, err
+		}
+
+		if err := insertStatusChange(ctx, tx, refund.OrderID, &status, models.OrderStatusRefunded, source, actor); err != nil {
+			return refund, err
+		}
+	}
+
+	return refund, tx.Commit(ctx)
+}
+
+// Fail records that payments-service could not give the refund back. Failed
+// refunds no longer count against what is left to refund.
+func (s *RefundStore) Fail(ctx context.Context, refundID, reason string) (models.Refund, error) {
+	return finishRefund(ctx, s.db, refundID, models.RefundStatusFailed, &reason)
+}
+
+// finishRefund moves a requested refund to its final status.
+func finishRefund(ctx context.Context, q querier, refundID, status string, failureReason *string) (models.Refund, error) {
+	query := `
+		UPDATE refunds
+		SET status = $2, failure_reason = $3, updated_at = NOW()
+		WHERE id = $1 AND status = $4
+		RETURNING ` + refundColumns
+
+	refund, err := scanRefund(q.QueryRow(ctx, query, refundID, status, failureReason, models.RefundStatusRequested))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return refund, ErrRefundNotInProgress
+	}
+
+	return refund, err
+}
+
+// requestRemainingRefund asks for everything not yet refunded of a paid order
+// back. It returns nil when nothing is left.
+func requestRemainingRefund(ctx context.Context, tx pgx.Tx, order models.Order, reason, actor string) (*models.Refund, error) {
+	remaining, err := remainingRefundAmount(ctx, tx, order)
+	if err != nil || remaining <= 0 {
+		return nil, err
+	}
+
+	refund := models.Refund{
+		OrderID:     order.ID,
+		Amount:      money.New(remaining, order.TotalPrice.Currency),
+		Reason:      reason,
+		RequestedBy: actor,
+	}
+	if err := insertRefund(ctx, tx, &refund); err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// remainingRefundAmount returns the part of the order total not covered by
+// refunds that succeeded or are still in progress.
+func remainingRefundAmount(ctx context.Context, tx pgx.Tx, order models.Order) (int64, error) {
+	var requested int64
+	err := tx.QueryRow(ctx, "SELECT COALESCE(SUM(amount), 0) FROM refunds WHERE order_id = $1 AND status <> $2", order.ID, models.RefundStatusFailed).
+		Scan(&requested)
+
+	return order.TotalPrice.Amount - requested, err
+}
+
+func insertRefund(ctx context.Context, tx pgx.Tx, refund *models.Refund) error {
+	query := `
+		INSERT INTO refunds (order_id, order_item_id, quantity, amount, currency, reason, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	return tx.QueryRow(ctx, query, refund.OrderID, refund.OrderItemID, refund.Quantity, refund.Amount.Amount, refund.Amount.Currency, refund.Reason, models.RefundStatusRequested, refund.RequestedBy).
+		Scan(&refund.ID, &refund.Status, &refund.CreatedAt, &refund.UpdatedAt)
+}
@@ -3,6 +3,8 @@ package store
 import (
 	"context"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/hours"
+
 	"github.com/MatTwix/Food-Delivery-Agregator/orders-service/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -17,14 +19,20 @@ func NewRestaurantStore(db *pgxpool.Pool) *RestaurantStore {
 
 func (s *RestaurantStore) Upsert(ctx context.Context, restaurant *models.Restaurant) error {
 	query := `
-		INSERT INTO restaurants (id, name, updated_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO restaurants (id, owner_id, name, staff_ids, timezone, opening_hours, latitude, longitude, updated_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, COALESCE($4::uuid[], '{}'), COALESCE(NULLIF($5, ''), 'UTC'), COALESCE($6::jsonb, '[]'), $8, $9, $7)
 		ON CONFLICT (id) DO UPDATE SET
+			owner_id = COALESCE(EXCLUDED.owner_id, restaurants.owner_id),
 			name = EXCLUDED.name,
+			staff_ids = EXCLUDED.staff_ids,
+			timezone = EXCLUDED.timezone,
+			opening_hours = EXCLUDED.opening_hours,
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
 			updated_at = EXCLUDED.updated_at;
 	`
 
-	_, err := s.db.Exec(ctx, query, restaurant.ID, restaurant.Name, restaurant.UpdatedAt)
+	_, err := s.db.Exec(ctx, query, restaurant.ID, restaurant.OwnerID, restaurant.Name, restaurant.StaffIDs, restaurant.Timezone, restaurant.OpeningHours, restaurant.UpdatedAt, restaurant.Latitude, restaurant.Longitude)
 
 	return err
 }
@@ -41,3 +49,60 @@ func (s *RestaurantStore) Delete(ctx context.Context, id string) error {
 
 	return err
 }
+
+// IsStaff reports whether the user is the owner or a staff member of the cached restaurant.
+func (s *RestaurantStore) IsStaff(ctx context.Context, restaurantID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM restaurants
+			WHERE id = $1 AND (owner_id = $2 OR $2 = ANY(staff_ids))
+		)
+	`
+
+	var isStaff bool
+	err := s.db.QueryRow(ctx, query, restaurantID, userID).Scan(&isStaff)
+
+	return isStaff, err
+}
+
+func (s *RestaurantStore) GetOpeningHours(ctx context.Context, id string) (timezone string, openingHours hours.Week, err error) {
+	query := `
+		SELECT timezone, opening_hours
+		FROM restaurants
+		WHERE id = $1
+	`
+
+	err = s.db.QueryRow(ctx, query, id).Scan(&timezone, &openingHours)
+
+	return timezone, openingHours, err
+}
+
+// GetLocation returns the coordinates of the cached restaurant, nil if it has
+// none.
+func (s *RestaurantStore) GetLocation(ctx context.Context, id string) (latitude, longitude *float64, err error) {
+	query := `
+		SELECT latitude, longitude
+		FROM restaurants
+		WHERE id = $1
+	`
+
+	err = s.db.QueryRow(ctx, query, id).Scan(&latitude, &longitude)
+
+	return latitude, longitude, err
+}
+
+func (s *RestaurantStore) Exists(ctx context.Context, id string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM restaurants
+			WHERE id = $1 AND is_active
+		)
+	`
+
+	var exists bool
+	err := s.db.QueryRow(ctx, query, id).Scan(&exists)
+
+	return exists, err
+}
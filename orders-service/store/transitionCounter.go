@@ -0,0 +1,35 @@
+package store
+
+import "sync"
+
+// transitionCounter counts order status transitions rejected by the state machine.
+type transitionCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newTransitionCounter() *transitionCounter {
+	return &transitionCounter{counts: make(map[string]int64)}
+}
+
+func (c *transitionCounter) add(from, to string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := from + "->" + to
+	c.counts[key]++
+
+	return c.counts[key]
+}
+
+func (c *transitionCounter) snapshot() map[string]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int64, len(c.counts))
+	for key, count := range c.counts {
+		counts[key] = count
+	}
+
+	return counts
+}
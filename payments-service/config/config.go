@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,6 +13,19 @@ type Config struct {
 	GRPC struct {
 		Port string `mapstructure:"port"`
 	} `mapstructure:"grpc"`
+	DB struct {
+		Source string `mapstructure:"source"`
+	} `mapstructure:"db"`
+	PaymentProvider struct {
+		Name string `mapstructure:"name"`
+		// Timeout bounds every call to the provider.
+		Timeout time.Duration `mapstructure:"timeout"`
+		Fake    struct {
+			Delay           time.Duration `mapstructure:"delay"`
+			DefaultScenario string        `mapstructure:"default_scenario"`
+			AmountScenarios bool          `mapstructure:"amount_scenarios"`
+		} `mapstructure:"fake"`
+	} `mapstructure:"payment_provider"`
 	Kafka struct {
 		Brokers  string `mapstructure:"brokers"`
 		GroupIDs struct {
@@ -21,6 +35,10 @@ type Config struct {
 			PaymentSucceeded string `mapstructure:"payment_succeeded"`
 			PaymentFailed    string `mapstructure:"payment_failed"`
 			PaymentRequested string `mapstructure:"payment_requested"`
+
+			RefundRequested string `mapstructure:"refund_requested"`
+			RefundSucceeded string `mapstructure:"refund_succeeded"`
+			RefundFailed    string `mapstructure:"refund_failed"`
 		} `mapstructure:"topics"`
 	} `mapstructure:"kafka"`
 }
@@ -9,7 +9,10 @@ import (
 
 	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/clients"
 	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/database"
 	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/messaging"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/provider"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/store"
 )
 
 func main() {
@@ -21,8 +24,13 @@ func main() {
 
 	messaging.InitTopicsNames()
 
+	database.NewConnection()
+	db := database.DB
+
 	messaging.InitTopics()
 
+	paymentStore := store.NewPaymentStore(db)
+
 	producer, err := messaging.NewProducer()
 	if err != nil {
 		slog.Error("failed to create producer", "error", err)
@@ -32,9 +40,18 @@ func main() {
 
 	orderGRPCClient := clients.NewOrderServiceClient()
 
-	messaging.StartConsumers(ctx, producer, orderGRPCClient)
+	paymentProvider, err := provider.New()
+	if err != nil {
+		slog.Error("failed to create payment provider", "error", err)
+		os.Exit(1)
+	}
+
+	messaging.StartConsumers(ctx, producer, orderGRPCClient, paymentProvider, paymentStore)
 
 	slog.Info("payments service started. Waiting for events...")
 	<-ctx.Done()
 	slog.Info("payments service shutting down")
+
+	database.DB.Close()
+	slog.Info("database connection closed")
 }
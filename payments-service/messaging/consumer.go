@@ -3,27 +3,82 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
-	"math/rand/v2"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 
 	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/models"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/provider"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/store"
+	"github.com/jackc/pgx/v5"
 	"github.com/segmentio/kafka-go"
 )
 
 type PaymentRequestedEvent struct {
-	OrderID    string  `json:"order_id"`
-	UserID     string  `json:"user_id"`
-	TotalPrice float64 `json:"total_price"`
+	OrderID    string      `json:"order_id"`
+	UserID     string      `json:"user_id"`
+	TotalPrice money.Money `json:"total_price"`
+	Attempt    int         `json:"attempt"`
+	CardToken  string      `json:"card_token,omitempty"`
 }
 
-func StartConsumers(ctx context.Context, p *Producer, ordersClient pb.OrderServiceClient) {
+// PaymentResultEvent is published as payment.succeeded or payment.failed.
+type PaymentResultEvent struct {
+	OrderID    string      `json:"order_id"`
+	UserID     string      `json:"user_id"`
+	TotalPrice money.Money `json:"total_price"`
+	PaymentID  string      `json:"payment_id,omitempty"`
+	Reason     string      `json:"reason,omitempty"`
+}
+
+// Reasons of a failed payment.
+const (
+	PaymentFailureDeclined          = "declined"
+	PaymentFailureInsufficientFunds = "insufficient_funds"
+	PaymentFailureTimeout           = "timeout"
+	PaymentFailureError             = "error"
+	PaymentFailureOrderMismatch     = "order_mismatch"
+)
+
+type RefundRequestedEvent struct {
+	RefundID string      `json:"refund_id"`
+	OrderID  string      `json:"order_id"`
+	UserID   string      `json:"user_id"`
+	Amount   money.Money `json:"amount"`
+	Reason   string      `json:"reason"`
+}
+
+// RefundResultEvent is published as refund.succeeded or refund.failed.
+type RefundResultEvent struct {
+	RefundID string      `json:"refund_id"`
+	OrderID  string      `json:"order_id"`
+	UserID   string      `json:"user_id"`
+	Amount   money.Money `json:"amount"`
+	Reason   string      `json:"reason,omitempty"`
+}
+
+// Reasons of a failed refund.
+const (
+	RefundFailurePaymentNotFound = "payment_not_found"
+	RefundFailureExceedsPayment  = "exceeds_payment"
+	RefundFailureTimeout         = "timeout"
+	RefundFailureError           = "error"
+)
+
+func StartConsumers(ctx context.Context, p *Producer, ordersClient pb.OrderServiceClient, paymentProvider provider.PaymentProvider, paymentStore *store.PaymentStore) {
 	go startTopicConsumer(ctx, PaymentRequestedTopic, config.Cfg.Kafka.GroupIDs.Orders, func(ctx context.Context, msg kafka.Message) {
-		handlePaymentRequested(ctx, msg, p, ordersClient)
+		handlePaymentRequested(ctx, msg, p, ordersClient, paymentProvider, paymentStore)
+	})
+
+	go startTopicConsumer(ctx, RefundRequestedTopic, config.Cfg.Kafka.GroupIDs.Orders, func(ctx context.Context, msg kafka.Message) {
+		handleRefundRequested(ctx, msg, p, paymentProvider, paymentStore)
 	})
 }
 
@@ -74,37 +129,340 @@ func startTopicConsumer(ctx context.Context, topic, groupID string, handler func
 	}
 }
 
-func handlePaymentRequested(ctx context.Context, msg kafka.Message, p *Producer, ordersClient pb.OrderServiceClient) {
+func handlePaymentRequested(ctx context.Context, msg kafka.Message, p *Producer, ordersClient pb.OrderServiceClient, paymentProvider provider.PaymentProvider, paymentStore *store.PaymentStore) {
 	var event PaymentRequestedEvent
 	if err := json.Unmarshal(msg.Value, &event); err != nil {
 		slog.Error("failed to unmarshal event", "event", PaymentRequestedTopic, "error", err)
 		return
 	}
 
+	// a request delivered twice, or sent again by the customer, only gets
+	// the outcome of the payment already made for it
+	if event.Attempt > 0 {
+		existing, err := paymentStore.GetByAttempt(ctx, event.OrderID, event.Attempt)
+		if err == nil {
+			republishPaymentResult(ctx, p, event, existing)
+			return
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Error("failed to get payment attempt", "order_id", event.OrderID, "attempt", event.Attempt, "error", err)
+			return
+		}
+	}
+
+	captured, err := paymentStore.GetCapturedByOrderID(ctx, event.OrderID)
+	if err == nil {
+		slog.Info("order is already paid, not charging again", "order_id", event.OrderID, "attempt", event.Attempt, "payment_id", captured.ID)
+		publishPaymentResult(ctx, p, PaymentSucceededTopic, event, captured.ID, "")
+		return
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		slog.Error("failed to get captured payment", "order_id", event.OrderID, "error", err)
+		return
+	}
+
+	order, err := ordersClient.GetOrderForPayment(ctx, &pb.GetOrderForPaymentRequest{
+		OrderId: event.OrderID,
+	})
+	if err != nil {
+		slog.Error("failed to get order for payment", "order_id", event.OrderID, "error", err)
+		return
+	}
+
+	if order.Status != "payment_failed" && order.Status != "pending" {
+		slog.Info("order status does not imply payment", "status", order.Status)
+		return
+	}
+
+	// the payer and the amount are only trusted as orders-service knows them
+	if totalPrice := money.FromProto(order.TotalPrice); event.UserID != order.UserId || event.TotalPrice != totalPrice {
+		slog.Error("payment request does not match the order, refusing to charge",
+			"order_id", event.OrderID, "attempt", event.Attempt,
+			"user_id", event.UserID, "order_user_id", order.UserId,
+			"total_price", event.TotalPrice, "order_total_price", totalPrice)
+
+		// the failure goes to the order owner, not to whoever the request named
+		event.UserID = order.UserId
+		event.TotalPrice = totalPrice
+		publishPaymentResult(ctx, p, PaymentFailedTopic, event, "", PaymentFailureOrderMismatch)
+		return
+	}
+
+	slog.Info("processing payment", "order_id", event.OrderID, "attempt", event.Attempt, "total_price", event.TotalPrice)
+
+	// the attempt is stored before the provider is called, so even attempts
+	// that never get an answer can be reconciled
+	payment := &models.Payment{
+		OrderID:  event.OrderID,
+		UserID:   event.UserID,
+		Attempt:  event.Attempt,
+		Amount:   event.TotalPrice,
+		Status:   models.PaymentStatusPending,
+		Provider: config.Cfg.PaymentProvider.Name,
+	}
+	created, err := paymentStore.Create(ctx, payment)
+	if err != nil {
+		slog.Error("failed to store payment attempt", "order_id", event.OrderID, "error", err)
+		return
+	}
+	if !created {
+		slog.Info("payment attempt is already being processed", "order_id", event.OrderID, "attempt", event.Attempt)
+		return
+	}
+
+	authorizeCtx, cancel := context.WithTimeout(ctx, config.Cfg.PaymentProvider.Timeout)
+	authorization, err := paymentProvider.Authorize(authorizeCtx, provider.AuthorizeRequest{
+		OrderID:   event.OrderID,
+		UserID:    event.UserID,
+		Amount:    event.TotalPrice,
+		CardToken: event.CardToken,
+	})
+	cancel()
+	if err != nil {
+		slog.Info("payment FAILED", "order_id", event.OrderID, "payment_id", payment.ID, "error", err)
+		failPayment(ctx, p, paymentStore, payment, event, paymentFailureReason(err))
+		return
+	}
+
+	if err := paymentStore.Authorize(ctx, payment.ID, authorization.ID); err != nil {
+		slog.Error("failed to store payment authorization", "payment_id", payment.ID, "provider_payment_id", authorization.ID, "error", err)
+	}
+
+	// the customer may have cancelled, or the order expired, while the charge
+	// was in flight; the authorization is then released uncaptured
 	resp, err := ordersClient.GetOrderStatus(ctx, &pb.GetOrderStatusRequest{
 		OrderId: event.OrderID,
 	})
+	if err == nil && resp.Status != "payment_failed" && resp.Status != "pending" {
+		slog.Info("order no longer awaits payment, not capturing", "order_id", event.OrderID, "status", resp.Status, "payment_id", payment.ID)
+		voidAuthorization(ctx, paymentProvider, payment, authorization.ID)
+		if err := paymentStore.Abandon(ctx, payment.ID); err != nil {
+			slog.Error("failed to store abandoned payment", "payment_id", payment.ID, "error", err)
+		}
+		return
+	}
+
+	captureCtx, cancel := context.WithTimeout(ctx, config.Cfg.PaymentProvider.Timeout)
+	_, err = paymentProvider.Capture(captureCtx, authorization.ID)
+	cancel()
+	if errors.Is(err, provider.ErrTimeout) {
+		err = captureOutcome(ctx, paymentProvider, authorization.ID, err)
+		if errors.Is(err, provider.ErrTimeout) {
+			// the money may have moved, so the payment stays authorized
+			// until the provider tells what happened to it
+			slog.Error("payment capture outcome is unknown, leaving it for reconciliation", "order_id", event.OrderID, "payment_id", payment.ID, "provider_payment_id", authorization.ID, "error", err)
+			return
+		}
+	}
+	if err != nil {
+		slog.Info("payment FAILED", "order_id", event.OrderID, "payment_id", payment.ID, "error", err)
+		voidAuthorization(ctx, paymentProvider, payment, authorization.ID)
+		failPayment(ctx, p, paymentStore, payment, event, paymentFailureReason(err))
+		return
+	}
+
+	// the money has moved at this point, so the order is paid even if
+	// recording the capture fails
+	if err := paymentStore.Capture(ctx, payment.ID, payment.Amount); err != nil {
+		slog.Error("payment captured but not recorded in the ledger", "payment_id", payment.ID, "provider_payment_id", authorization.ID, "error", err)
+	}
+
+	slog.Info("payment SUCCEEDED", "order_id", event.OrderID, "payment_id", payment.ID)
+	publishPaymentResult(ctx, p, PaymentSucceededTopic, event, payment.ID, "")
+}
+
+// captureOutcome asks the provider what became of a capture that timed out.
+// It returns nil when the payment was captured, ErrInvalidPaymentState when
+// it was not, so the capture failed for sure, and the timeout when the
+// provider cannot tell either.
+func captureOutcome(ctx context.Context, paymentProvider provider.PaymentProvider, providerPaymentID string, timeoutErr error) error {
+	statusCtx, cancel := context.WithTimeout(ctx, config.Cfg.PaymentProvider.Timeout)
+	defer cancel()
+
+	status, err := paymentProvider.Status(statusCtx, providerPaymentID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", timeoutErr, err)
+	}
+
+	switch status.Status {
+	case provider.PaymentStatusCaptured, provider.PaymentStatusRefunded:
+		return nil
+	case provider.PaymentStatusAuthorized:
+		// the capture never reached the provider, so it is a definite failure
+		return fmt.Errorf("capture not applied: %w", provider.ErrInvalidPaymentState)
+	default:
+		return fmt.Errorf("payment is %s: %w", status.Status, provider.ErrInvalidPaymentState)
+	}
+}
+
+// voidAuthorization releases an authorization that will not be captured, so
+// the money is not held on the card until it lapses.
+func voidAuthorization(ctx context.Context, paymentProvider provider.PaymentProvider, payment *models.Payment, providerPaymentID string) {
+	voidCtx, cancel := context.WithTimeout(ctx, config.Cfg.PaymentProvider.Timeout)
+	defer cancel()
+
+	if _, err := paymentProvider.Void(voidCtx, providerPaymentID); err != nil {
+		slog.Error("failed to void payment authorization", "payment_id", payment.ID, "provider_payment_id", providerPaymentID, "error", err)
+	}
+}
+
+func failPayment(ctx context.Context, p *Producer, paymentStore *store.PaymentStore, payment *models.Payment, event PaymentRequestedEvent, reason string) {
+	if err := paymentStore.Fail(ctx, payment.ID, reason); err != nil {
+		slog.Error("failed to store payment failure", "payment_id", payment.ID, "error", err)
+	}
+
+	publishPaymentResult(ctx, p, PaymentFailedTopic, event, payment.ID, reason)
+}
+
+// republishPaymentResult answers a duplicate payment request with the
+// outcome of the payment stored for it. Attempts still in flight publish
+// their outcome once they are done, and abandoned ones have none.
+func republishPaymentResult(ctx context.Context, p *Producer, event PaymentRequestedEvent, payment models.Payment) {
+	slog.Info("duplicate payment request", "order_id", event.OrderID, "attempt", event.Attempt, "payment_id", payment.ID, "status", payment.Status)
+
+	switch payment.Status {
+	case models.PaymentStatusCaptured, models.PaymentStatusRefunded:
+		publishPaymentResult(ctx, p, PaymentSucceededTopic, event, payment.ID, "")
+	case models.PaymentStatusFailed:
+		reason := PaymentFailureError
+		if payment.FailureReason != nil {
+			reason = *payment.FailureReason
+		}
+		publishPaymentResult(ctx, p, PaymentFailedTopic, event, payment.ID, reason)
+	}
+}
+
+func paymentFailureReason(err error) string {
+	switch {
+	case errors.Is(err, provider.ErrDeclined):
+		return PaymentFailureDeclined
+	case errors.Is(err, provider.ErrInsufficientFunds):
+		return PaymentFailureInsufficientFunds
+	case errors.Is(err, provider.ErrTimeout):
+		return PaymentFailureTimeout
+	default:
+		return PaymentFailureError
+	}
+}
+
+func publishPaymentResult(ctx context.Context, p *Producer, topic string, event PaymentRequestedEvent, paymentID, reason string) {
+	eventBody, err := json.Marshal(PaymentResultEvent{
+		OrderID:    event.OrderID,
+		UserID:     event.UserID,
+		TotalPrice: event.TotalPrice,
+		PaymentID:  paymentID,
+		Reason:     reason,
+	})
+	if err != nil {
+		slog.Error("failed to marshal event", "event", topic, "error", err)
+		return
+	}
+
+	p.Produce(ctx, topic, []byte(event.OrderID), eventBody)
+}
+
+// handleRefundRequested gives back part or all of the captured payment of
+// the order. Refunds are stored under the ID orders-service gave them, so a
+// redelivered request is not refunded twice.
+func handleRefundRequested(ctx context.Context, msg kafka.Message, p *Producer, paymentProvider provider.PaymentProvider, paymentStore *store.PaymentStore) {
+	var event RefundRequestedEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.Error("failed to unmarshal event", "event", RefundRequestedTopic, "error", err)
+		return
+	}
+
+	payment, err := paymentStore.GetCapturedByOrderID(ctx, event.OrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			slog.Error("refund FAILED, no captured payment found for order", "order_id", event.OrderID, "refund_id", event.RefundID)
+			publishRefundResult(ctx, p, RefundFailedTopic, event, RefundFailurePaymentNotFound)
+			return
+		}
+		slog.Error("failed to get captured payment", "order_id", event.OrderID, "error", err)
+		return
+	}
+
+	if payment.ProviderPaymentID == nil {
+		slog.Error("refund FAILED, payment has no provider reference", "payment_id", payment.ID, "refund_id", event.RefundID)
+		publishRefundResult(ctx, p, RefundFailedTopic, event, RefundFailurePaymentNotFound)
+		return
+	}
+
+	remaining, err := payment.Amount.Subtract(payment.RefundedAmount)
+	if err != nil || event.Amount.Currency != remaining.Currency || event.Amount.Amount > remaining.Amount {
+		slog.Error("refund FAILED, amount exceeds what is left of the payment", "payment_id", payment.ID, "refund_id", event.RefundID, "amount", event.Amount, "remaining", remaining)
+		publishRefundResult(ctx, p, RefundFailedTopic, event, RefundFailureExceedsPayment)
+		return
+	}
+
+	refund := &models.Refund{
+		ID:        event.RefundID,
+		PaymentID: payment.ID,
+		OrderID:   event.OrderID,
+		Amount:    event.Amount,
+	}
+	created, err := paymentStore.CreateRefund(ctx, refund)
 	if err != nil {
-		slog.Error("failed to check order status", "error", err)
+		slog.Error("failed to store refund", "refund_id", event.RefundID, "error", err)
+		return
+	}
+	if !created {
+		slog.Info("refund was already requested, skipping", "refund_id", event.RefundID)
 		return
 	}
 
-	if resp.Status != "payment_failed" && resp.Status != "pending" {
-		slog.Info("order status does not imply payment", "status", resp.Status)
+	slog.Info("processing refund", "order_id", event.OrderID, "payment_id", payment.ID, "refund_id", refund.ID, "amount", refund.Amount)
+
+	refundCtx, cancel := context.WithTimeout(ctx, config.Cfg.PaymentProvider.Timeout)
+	_, err = paymentProvider.Refund(refundCtx, *payment.ProviderPaymentID, refund.Amount)
+	cancel()
+	if err != nil {
+		slog.Error("refund FAILED", "order_id", event.OrderID, "refund_id", refund.ID, "error", err)
+
+		reason := refundFailureReason(err)
+		if err := paymentStore.FailRefund(ctx, refund.ID, reason); err != nil {
+			slog.Error("failed to store refund failure", "refund_id", refund.ID, "error", err)
+		}
+
+		publishRefundResult(ctx, p, RefundFailedTopic, event, reason)
 		return
 	}
 
-	slog.Info("processing payment", "order_id", event.OrderID, "total_price", event.TotalPrice)
+	// the money is back with the customer at this point, so the refund has
+	// succeeded even if recording it fails
+	if err := paymentStore.CompleteRefund(ctx, refund.ID); err != nil {
+		slog.Error("refund made but not recorded in the ledger", "payment_id", payment.ID, "refund_id", refund.ID, "error", err)
+	}
 
-	// imitating payment process
+	slog.Info("refund SUCCEEDED", "order_id", event.OrderID, "payment_id", payment.ID, "refund_id", refund.ID)
+	publishRefundResult(ctx, p, RefundSucceededTopic, event, "")
+}
 
-	time.Sleep(5 * time.Second)
+func refundFailureReason(err error) string {
+	switch {
+	case errors.Is(err, provider.ErrPaymentNotFound):
+		return RefundFailurePaymentNotFound
+	case errors.Is(err, provider.ErrInvalidRefundAmount), errors.Is(err, provider.ErrInvalidPaymentState):
+		return RefundFailureExceedsPayment
+	case errors.Is(err, provider.ErrTimeout):
+		return RefundFailureTimeout
+	default:
+		return RefundFailureError
+	}
+}
 
-	if rand.Float32() < 0.8 {
-		slog.Info("payment SUCCEEDED", "order_id", event.OrderID)
-		p.Produce(ctx, PaymentSucceededTopic, []byte(event.OrderID), msg.Value)
-	} else {
-		slog.Info("payment FAILED", "order_id", event.OrderID)
-		p.Produce(ctx, PaymentFailedTopic, []byte(event.OrderID), msg.Value)
+func publishRefundResult(ctx context.Context, p *Producer, topic string, event RefundRequestedEvent, reason string) {
+	eventBody, err := json.Marshal(RefundResultEvent{
+		RefundID: event.RefundID,
+		OrderID:  event.OrderID,
+		UserID:   event.UserID,
+		Amount:   event.Amount,
+		Reason:   reason,
+	})
+	if err != nil {
+		slog.Error("failed to marshal event", "event", topic, "error", err)
+		return
 	}
+
+	p.Produce(ctx, topic, []byte(event.OrderID), eventBody)
 }
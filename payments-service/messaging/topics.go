@@ -15,6 +15,10 @@ var (
 	PaymentSucceededTopic string
 	PaymentFailedTopic    string
 	PaymentRequestedTopic string
+
+	RefundRequestedTopic string
+	RefundSucceededTopic string
+	RefundFailedTopic    string
 )
 
 var Topics []string
@@ -24,10 +28,18 @@ func InitTopicsNames() {
 	PaymentFailedTopic = config.Cfg.Kafka.Topics.PaymentFailed
 	PaymentRequestedTopic = config.Cfg.Kafka.Topics.PaymentRequested
 
+	RefundRequestedTopic = config.Cfg.Kafka.Topics.RefundRequested
+	RefundSucceededTopic = config.Cfg.Kafka.Topics.RefundSucceeded
+	RefundFailedTopic = config.Cfg.Kafka.Topics.RefundFailed
+
 	Topics = []string{
 		PaymentSucceededTopic,
 		PaymentFailedTopic,
 		PaymentRequestedTopic,
+
+		RefundRequestedTopic,
+		RefundSucceededTopic,
+		RefundFailedTopic,
 	}
 }
 
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddPaymentsAttempt adds the attempt number orders-service gave the payment
+// request. Payments stored before requests were numbered keep attempt 0 and
+// are left out of the unique key.
+func AddPaymentsAttempt(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt INT NOT NULL DEFAULT 0;
+
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_attempt ON payments(order_id, attempt) WHERE attempt > 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add payments attempt column", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddPaymentsRefundedAmount adds the part of a captured payment given back
+// to the customer, so partial refunds can be checked against what is left.
+func AddPaymentsRefundedAmount(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount BIGINT NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		slog.Error("failed to add payments refunded_amount column", "error", err)
+		os.Exit(1)
+	}
+}
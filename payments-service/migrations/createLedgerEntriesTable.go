@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateLedgerEntriesTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'ledger_entries');").
+		Scan(&tableExists)
+	if err != nil {
+		slog.Error("failed to check ledger_entries table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		// entries sharing a transaction_id are one balanced posting: their
+		// debits and credits add up to the same amount
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE ledger_entries (
+				id BIGSERIAL PRIMARY KEY,
+				transaction_id UUID NOT NULL,
+				payment_id UUID NOT NULL REFERENCES payments(id),
+				account VARCHAR(50) NOT NULL,
+				direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+				amount BIGINT NOT NULL CHECK (amount > 0),
+				currency VARCHAR(3) NOT NULL,
+				description VARCHAR(255) NOT NULL,
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_ledger_entries_payment_id ON ledger_entries(payment_id);
+			CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+			CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries(account, created_at);
+		`)
+
+		if err != nil {
+			slog.Error("failed to create ledger_entries table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("ledger_entries table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreatePaymentsTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'payments');").
+		Scan(&tableExists)
+	if err != nil {
+		slog.Error("failed to check payments table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		// every payment attempt is a row, so an order may have several
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE payments (
+				id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+				order_id UUID NOT NULL,
+				user_id UUID NOT NULL,
+				amount BIGINT NOT NULL CHECK (amount >= 0),
+				currency VARCHAR(3) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				provider VARCHAR(50) NOT NULL,
+				provider_payment_id VARCHAR(255),
+				failure_reason VARCHAR(50),
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+		`)
+
+		if err != nil {
+			slog.Error("failed to create payments table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("payments table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func CreateRefundsTable(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var tableExists bool
+	err = tx.QueryRow(ctx,
+		"SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'refunds');").
+		Scan(&tableExists)
+	if err != nil {
+		slog.Error("failed to check refunds table existance", "error", err)
+		os.Exit(1)
+	}
+
+	if !tableExists {
+		// id is the refund id assigned by orders-service, so a redelivered
+		// refund request cannot refund the payment twice
+		_, err = tx.Exec(ctx, `
+			CREATE TABLE refunds (
+				id UUID PRIMARY KEY,
+				payment_id UUID NOT NULL REFERENCES payments(id),
+				order_id UUID NOT NULL,
+				amount BIGINT NOT NULL CHECK (amount > 0),
+				currency VARCHAR(3) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				failure_reason VARCHAR(50),
+				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds(payment_id);
+		`)
+		if err != nil {
+			slog.Error("failed to create refunds table", "error", err)
+			os.Exit(1)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			slog.Error("failed to commit transaction", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("refunds table created successfully")
+	} else {
+		tx.Rollback(ctx)
+	}
+}
@@ -0,0 +1,11 @@
+package migrations
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+func Migrate(db *pgxpool.Pool) {
+	CreatePaymentsTable(db)
+	CreateLedgerEntriesTable(db)
+	AddPaymentsRefundedAmount(db)
+	AddPaymentsAttempt(db)
+	CreateRefundsTable(db)
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+const (
+	LedgerDirectionDebit  = "debit"
+	LedgerDirectionCredit = "credit"
+)
+
+const (
+	// LedgerAccountProviderClearing is money the payment provider holds for us.
+	LedgerAccountProviderClearing = "provider_clearing"
+	// LedgerAccountCustomerPayments is money customers paid for their orders.
+	LedgerAccountCustomerPayments = "customer_payments"
+)
+
+// LedgerEntry is one side of a double-entry posting. The entries of a
+// posting share TransactionID and their debits equal their credits.
+type LedgerEntry struct {
+	ID            int64       `json:"id"`
+	TransactionID string      `json:"transaction_id"`
+	PaymentID     string      `json:"payment_id"`
+	Account       string      `json:"account"`
+	Direction     string      `json:"direction"`
+	Amount        money.Money `json:"amount"`
+	Description   string      `json:"description"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+const (
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusFailed     = "failed"
+	// PaymentStatusAbandoned is an authorization left to lapse uncaptured
+	// because the order stopped waiting for payment meanwhile.
+	PaymentStatusAbandoned = "abandoned"
+	// PaymentStatusRefunded is a captured payment given back in full.
+	// Partially refunded payments stay captured.
+	PaymentStatusRefunded = "refunded"
+)
+
+// Payment is one attempt to charge an order, numbered by Attempt as in the
+// payment request. ProviderPaymentID is set once the provider has authorized
+// the payment.
+type Payment struct {
+	ID                string      `json:"id"`
+	OrderID           string      `json:"order_id"`
+	UserID            string      `json:"user_id"`
+	Attempt           int         `json:"attempt"`
+	Amount            money.Money `json:"amount"`
+	RefundedAmount    money.Money `json:"refunded_amount"`
+	Status            string      `json:"status"`
+	Provider          string      `json:"provider"`
+	ProviderPaymentID *string     `json:"provider_payment_id"`
+	FailureReason     *string     `json:"failure_reason"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
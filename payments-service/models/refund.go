@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund gives back part or all of a captured payment. Its ID is the one
+// orders-service assigned to the refund.
+type Refund struct {
+	ID            string      `json:"id"`
+	PaymentID     string      `json:"payment_id"`
+	OrderID       string      `json:"order_id"`
+	Amount        money.Money `json:"amount"`
+	Status        string      `json:"status"`
+	FailureReason *string     `json:"failure_reason"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+// Scenarios of the fake provider.
+const (
+	ScenarioSuccess           = "success"
+	ScenarioDeclined          = "declined"
+	ScenarioInsufficientFunds = "insufficient_funds"
+	ScenarioTimeout           = "timeout"
+)
+
+var scenarios = []string{ScenarioSuccess, ScenarioDeclined, ScenarioInsufficientFunds, ScenarioTimeout}
+
+// cardTokenPrefix followed by a scenario, e.g. "tok_declined", forces that
+// scenario whatever the amount.
+const cardTokenPrefix = "tok_"
+
+// amountScenarios force a scenario by the minor units of the amount, so an
+// amount of 25.01 is declined. They are off unless the fake is created with
+// them, since real totals end in these digits too.
+var amountScenarios = map[int64]string{
+	1: ScenarioDeclined,
+	2: ScenarioInsufficientFunds,
+	3: ScenarioTimeout,
+}
+
+// FakeProvider is an in-memory PaymentProvider. Its outcome only depends on
+// the card token and the amount, so tests can pick every path on purpose.
+// Its payments are lost on restart, so payments captured before one can no
+// longer be refunded.
+type FakeProvider struct {
+	delay           time.Duration
+	defaultScenario string
+	amountScenarios bool
+
+	mu       sync.Mutex
+	nextID   int
+	payments map[string]*Payment
+}
+
+// NewFakeProvider returns a fake answering after delay. Authorizations not
+// forced into a scenario by card token, or by amount if amountScenarios is
+// set, get defaultScenario.
+func NewFakeProvider(delay time.Duration, defaultScenario string, amountScenarios bool) (*FakeProvider, error) {
+	if defaultScenario == "" {
+		defaultScenario = ScenarioSuccess
+	}
+	if !slices.Contains(scenarios, defaultScenario) {
+		return nil, fmt.Errorf("unknown fake payment scenario %q", defaultScenario)
+	}
+
+	return &FakeProvider{
+		delay:           delay,
+		defaultScenario: defaultScenario,
+		amountScenarios: amountScenarios,
+		payments:        make(map[string]*Payment),
+	}, nil
+}
+
+func (p *FakeProvider) scenario(req AuthorizeRequest) string {
+	if token, ok := strings.CutPrefix(req.CardToken, cardTokenPrefix); ok && slices.Contains(scenarios, token) {
+		return token
+	}
+	if scenario, ok := amountScenarios[req.Amount.Amount%100]; ok && p.amountScenarios {
+		return scenario
+	}
+
+	return p.defaultScenario
+}
+
+// wait imitates the gateway round trip.
+func (p *FakeProvider) wait(ctx context.Context) error {
+	select {
+	case <-time.After(p.delay):
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+	}
+}
+
+func (p *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (Payment, error) {
+	scenario := p.scenario(req)
+
+	// a timing out gateway never answers, the caller's deadline ends the call
+	if scenario == ScenarioTimeout {
+		<-ctx.Done()
+		return Payment{}, fmt.Errorf("%w: %w", ErrTimeout, ctx.Err())
+	}
+
+	if err := p.wait(ctx); err != nil {
+		return Payment{}, err
+	}
+
+	switch scenario {
+	case ScenarioDeclined:
+		return Payment{}, ErrDeclined
+	case ScenarioInsufficientFunds:
+		return Payment{}, ErrInsufficientFunds
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.nextID++
+	payment := &Payment{
+		ID:       fmt.Sprintf("fake_pay_%d", p.nextID),
+		OrderID:  req.OrderID,
+		Amount:   req.Amount,
+		Refunded: money.New(0, req.Amount.Currency),
+		Status:   PaymentStatusAuthorized,
+	}
+	p.payments[payment.ID] = payment
+
+	return *payment, nil
+}
+
+func (p *FakeProvider) Capture(ctx context.Context, paymentID string) (Payment, error) {
+	if err := p.wait(ctx); err != nil {
+		return Payment{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return Payment{}, ErrPaymentNotFound
+	}
+	if payment.Status != PaymentStatusAuthorized {
+		return Payment{}, ErrInvalidPaymentState
+	}
+
+	payment.Status = PaymentStatusCaptured
+
+	return *payment, nil
+}
+
+func (p *FakeProvider) Void(ctx context.Context, paymentID string) (Payment, error) {
+	if err := p.wait(ctx); err != nil {
+		return Payment{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return Payment{}, ErrPaymentNotFound
+	}
+	if payment.Status != PaymentStatusAuthorized {
+		return Payment{}, ErrInvalidPaymentState
+	}
+
+	payment.Status = PaymentStatusVoided
+
+	return *payment, nil
+}
+
+func (p *FakeProvider) Refund(ctx context.Context, paymentID string, amount money.Money) (Payment, error) {
+	if err := p.wait(ctx); err != nil {
+		return Payment{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return Payment{}, ErrPaymentNotFound
+	}
+	if payment.Status != PaymentStatusCaptured {
+		return Payment{}, ErrInvalidPaymentState
+	}
+
+	refunded, err := payment.Refunded.Add(amount)
+	if err != nil {
+		return Payment{}, err
+	}
+	if amount.Amount <= 0 || refunded.Amount > payment.Amount.Amount {
+		return Payment{}, ErrInvalidRefundAmount
+	}
+
+	payment.Refunded = refunded
+	if refunded.Amount == payment.Amount.Amount {
+		payment.Status = PaymentStatusRefunded
+	}
+
+	return *payment, nil
+}
+
+func (p *FakeProvider) Status(ctx context.Context, paymentID string) (Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[paymentID]
+	if !ok {
+		return Payment{}, ErrPaymentNotFound
+	}
+
+	return *payment, nil
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
+
+// testTimeout bounds calls that only end with their context.
+const testTimeout = 20 * time.Millisecond
+
+func newTestFake(t *testing.T, delay time.Duration, defaultScenario string, amountScenarios bool) *FakeProvider {
+	t.Helper()
+
+	p, err := NewFakeProvider(delay, defaultScenario, amountScenarios)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func authorize(p *FakeProvider, cardToken string, amount int64) (Payment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	return p.Authorize(ctx, AuthorizeRequest{
+		OrderID:   "order-1",
+		UserID:    "user-1",
+		Amount:    money.New(amount, "USD"),
+		CardToken: cardToken,
+	})
+}
+
+func TestFakeProviderScenarios(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultScenario string
+		amountScenarios bool
+		cardToken       string
+		amount          int64
+		wantErr         error
+	}{
+		{name: "default success", amount: 2500},
+		{name: "default declined", defaultScenario: ScenarioDeclined, amount: 2500, wantErr: ErrDeclined},
+
+		{name: "token success", cardToken: "tok_success", amount: 2500},
+		{name: "token declined", cardToken: "tok_declined", amount: 2500, wantErr: ErrDeclined},
+		{name: "token insufficient funds", cardToken: "tok_insufficient_funds", amount: 2500, wantErr: ErrInsufficientFunds},
+		{name: "token timeout", cardToken: "tok_timeout", amount: 2500, wantErr: ErrTimeout},
+		{name: "unknown token falls back to default", cardToken: "tok_unknown", defaultScenario: ScenarioDeclined, amount: 2500, wantErr: ErrDeclined},
+		{name: "token without prefix is ignored", cardToken: "declined", amount: 2500},
+
+		{name: "amount ending in 01 declined", amountScenarios: true, amount: 2501, wantErr: ErrDeclined},
+		{name: "amount ending in 02 insufficient funds", amountScenarios: true, amount: 2502, wantErr: ErrInsufficientFunds},
+		{name: "amount ending in 03 timeout", amountScenarios: true, amount: 2503, wantErr: ErrTimeout},
+		{name: "amount ending in 04 default", amountScenarios: true, defaultScenario: ScenarioInsufficientFunds, amount: 2504, wantErr: ErrInsufficientFunds},
+		{name: "amount ignored when off", amount: 2501},
+		{name: "amount off falls back to default", defaultScenario: ScenarioDeclined, amount: 2502, wantErr: ErrDeclined},
+
+		{name: "token wins over amount", amountScenarios: true, cardToken: "tok_success", amount: 2501},
+		{name: "amount wins over default", amountScenarios: true, defaultScenario: ScenarioDeclined, amount: 2502, wantErr: ErrInsufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestFake(t, 0, tt.defaultScenario, tt.amountScenarios)
+
+			payment, err := authorize(p, tt.cardToken, tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if payment.Status != PaymentStatusAuthorized {
+				t.Errorf("Authorize() status = %q, want %q", payment.Status, PaymentStatusAuthorized)
+			}
+			if payment.Amount != money.New(tt.amount, "USD") {
+				t.Errorf("Authorize() amount = %v, want %d USD", payment.Amount, tt.amount)
+			}
+		})
+	}
+}
+
+func TestFakeProviderTimeout(t *testing.T) {
+	tests := []struct {
+		name      string
+		delay     time.Duration
+		cardToken string
+		cancel    bool
+		wantCause error
+	}{
+		{name: "timeout scenario ends at the deadline", cardToken: "tok_timeout", wantCause: context.DeadlineExceeded},
+		{name: "timeout scenario ends on cancel", cardToken: "tok_timeout", cancel: true, wantCause: context.Canceled},
+		{name: "slow gateway ends at the deadline", delay: time.Minute, cardToken: "tok_success", wantCause: context.DeadlineExceeded},
+		{name: "slow gateway ends on cancel", delay: time.Minute, cardToken: "tok_success", cancel: true, wantCause: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestFake(t, tt.delay, "", false)
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			start := time.Now()
+			_, err := p.Authorize(ctx, AuthorizeRequest{
+				OrderID:   "order-1",
+				Amount:    money.New(2500, "USD"),
+				CardToken: tt.cardToken,
+			})
+
+			if !errors.Is(err, ErrTimeout) || !errors.Is(err, tt.wantCause) {
+				t.Fatalf("Authorize() error = %v, want %v caused by %v", err, ErrTimeout, tt.wantCause)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Authorize() returned after %v, want it to end with the context", elapsed)
+			}
+		})
+	}
+}
+
+func TestFakeProviderDeterministic(t *testing.T) {
+	tests := []struct {
+		name      string
+		cardToken string
+		amount    int64
+		wantErr   error
+	}{
+		{name: "success", amount: 2500},
+		{name: "declined by token", cardToken: "tok_declined", amount: 2500, wantErr: ErrDeclined},
+		{name: "insufficient funds by amount", amount: 2502, wantErr: ErrInsufficientFunds},
+		{name: "timeout by amount", amount: 2503, wantErr: ErrTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestFake(t, 0, "", true)
+			first, _ := authorize(newTestFake(t, 0, "", true), tt.cardToken, tt.amount)
+
+			// earlier calls on the same provider do not change the outcome,
+			// and a fresh provider answers the same request the same way
+			for range 3 {
+				if _, err := authorize(p, tt.cardToken, tt.amount); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("repeated Authorize() error = %v, want %v", err, tt.wantErr)
+				}
+
+				payment, err := authorize(newTestFake(t, 0, "", true), tt.cardToken, tt.amount)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("fresh Authorize() error = %v, want %v", err, tt.wantErr)
+				}
+				if payment != first {
+					t.Fatalf("fresh Authorize() = %+v, want %+v", payment, first)
+				}
+			}
+		})
+	}
+}
+
+func TestNewFakeProviderUnknownScenario(t *testing.T) {
+	if _, err := NewFakeProvider(0, "bogus", false); err == nil {
+		t.Fatal("NewFakeProvider() error = nil, want an error for an unknown scenario")
+	}
+}
+
+func TestFakeProviderVoid(t *testing.T) {
+	tests := []struct {
+		name    string
+		capture bool
+		wantErr error
+	}{
+		{name: "authorized payment is voided"},
+		{name: "captured payment cannot be voided", capture: true, wantErr: ErrInvalidPaymentState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestFake(t, 0, "", false)
+			ctx := context.Background()
+
+			payment, err := authorize(p, "tok_success", 2500)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.capture {
+				if _, err := p.Capture(ctx, payment.ID); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			voided, err := p.Void(ctx, payment.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Void() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if voided.Status != PaymentStatusVoided {
+				t.Errorf("Void() status = %q, want %q", voided.Status, PaymentStatusVoided)
+			}
+			if _, err := p.Capture(ctx, payment.ID); !errors.Is(err, ErrInvalidPaymentState) {
+				t.Errorf("Capture() after Void() error = %v, want %v", err, ErrInvalidPaymentState)
+			}
+		})
+	}
+
+	if _, err := newTestFake(t, 0, "", false).Void(context.Background(), "fake_pay_missing"); !errors.Is(err, ErrPaymentNotFound) {
+		t.Errorf("Void() of an unknown payment error = %v, want %v", err, ErrPaymentNotFound)
+	}
+}
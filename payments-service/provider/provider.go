@@ -0,0 +1,72 @@
+// Package provider is the boundary between payments-service and the payment
+// gateway that moves the money.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/config"
+)
+
+const (
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusRefunded   = "refunded"
+	PaymentStatusVoided     = "voided"
+)
+
+var (
+	ErrDeclined          = errors.New("payment declined")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrTimeout means the gateway did not answer in time, so the outcome of
+	// the call is unknown.
+	ErrTimeout             = errors.New("payment provider timed out")
+	ErrPaymentNotFound     = errors.New("payment not found")
+	ErrInvalidPaymentState = errors.New("payment is not in a state allowing this operation")
+	ErrInvalidRefundAmount = errors.New("refund amount must be positive and within the captured amount")
+)
+
+type AuthorizeRequest struct {
+	OrderID string
+	UserID  string
+	Amount  money.Money
+	// CardToken identifies the card to charge at the provider.
+	CardToken string
+}
+
+// Payment is a payment as the provider knows it. Refunded is the part of
+// Amount given back so far.
+type Payment struct {
+	ID       string
+	OrderID  string
+	Amount   money.Money
+	Refunded money.Money
+	Status   string
+}
+
+// PaymentProvider is a payment gateway. Money is first authorized, i.e.
+// reserved on the card, and only moved by Capture; Void releases an
+// authorization that will not be captured. Captured payments can be refunded
+// in full or in parts.
+type PaymentProvider interface {
+	Authorize(ctx context.Context, req AuthorizeRequest) (Payment, error)
+	Capture(ctx context.Context, paymentID string) (Payment, error)
+	Void(ctx context.Context, paymentID string) (Payment, error)
+	Refund(ctx context.Context, paymentID string, amount money.Money) (Payment, error)
+	Status(ctx context.Context, paymentID string) (Payment, error)
+}
+
+// New returns the provider selected by payment_provider.name.
+func New() (PaymentProvider, error) {
+	cfg := config.Cfg.PaymentProvider
+
+	switch cfg.Name {
+	case "fake":
+		return NewFakeProvider(cfg.Fake.Delay, cfg.Fake.DefaultScenario, cfg.Fake.AmountScenarios)
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Name)
+	}
+}
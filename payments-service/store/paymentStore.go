@@ -0,0 +1,330 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+	"github.com/MatTwix/Food-Delivery-Agregator/payments-service/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrInvalidPaymentStatus = errors.New("payment status does not allow this change")
+	ErrInvalidRefundStatus  = errors.New("refund status does not allow this change")
+)
+
+type PaymentStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentStore(db *pgxpool.Pool) *PaymentStore {
+	return &PaymentStore{db: db}
+}
+
+const paymentColumns = `id, order_id, user_id, attempt, amount, refunded_amount, currency, status, provider, provider_payment_id, failure_reason, created_at, updated_at`
+
+func scanPayment(row pgx.Row) (models.Payment, error) {
+	var payment models.Payment
+	err := row.Scan(
+		&payment.ID,
+		&payment.OrderID,
+		&payment.UserID,
+		&payment.Attempt,
+		&payment.Amount.Amount,
+		&payment.RefundedAmount.Amount,
+		&payment.Amount.Currency,
+		&payment.Status,
+		&payment.Provider,
+		&payment.ProviderPaymentID,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	payment.RefundedAmount.Currency = payment.Amount.Currency
+
+	return payment, err
+}
+
+// Create stores a new payment attempt before the provider is called. It
+// returns false without storing anything if the attempt of the order is
+// already stored.
+func (s *PaymentStore) Create(ctx context.Context, payment *models.Payment) (bool, error) {
+	query := `
+		INSERT INTO payments (order_id, user_id, attempt, amount, currency, status, provider)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (order_id, attempt) WHERE attempt > 0 DO NOTHING
+		RETURNING id, created_at, updated_at
+	`
+
+	err := s.db.QueryRow(ctx, query, payment.OrderID, payment.UserID, payment.Attempt, payment.Amount.Amount, payment.Amount.Currency, payment.Status, payment.Provider).
+		Scan(&payment.ID, &payment.CreatedAt, &payment.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetByAttempt returns the payment of the given attempt of the order or
+// pgx.ErrNoRows if the attempt was never processed.
+func (s *PaymentStore) GetByAttempt(ctx context.Context, orderID string, attempt int) (models.Payment, error) {
+	query := "SELECT " + paymentColumns + " FROM payments WHERE order_id = $1 AND attempt = $2"
+
+	return scanPayment(s.db.QueryRow(ctx, query, orderID, attempt))
+}
+
+// GetCapturedByOrderID returns the latest captured payment of the order, which
+// may be partially refunded, or pgx.ErrNoRows if the order was never charged.
+func (s *PaymentStore) GetCapturedByOrderID(ctx context.Context, orderID string) (models.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM payments
+		WHERE order_id = $1 AND status = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return scanPayment(s.db.QueryRow(ctx, query, orderID, models.PaymentStatusCaptured))
+}
+
+// Authorize records the provider authorization of a pending payment.
+func (s *PaymentStore) Authorize(ctx context.Context, paymentID, providerPaymentID string) error {
+	return updateStatus(ctx, s.db, paymentID, models.PaymentStatusPending, models.PaymentStatusAuthorized, &providerPaymentID, nil)
+}
+
+// Fail records why a pending or authorized payment failed.
+func (s *PaymentStore) Fail(ctx context.Context, paymentID, reason string) error {
+	query := `
+		UPDATE payments
+		SET status = $1, failure_reason = $2, updated_at = NOW()
+		WHERE id = $3 AND status = ANY($4)
+	`
+
+	tag, err := s.db.Exec(ctx, query, models.PaymentStatusFailed, reason, paymentID, []string{models.PaymentStatusPending, models.PaymentStatusAuthorized})
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidPaymentStatus
+	}
+
+	return nil
+}
+
+// Abandon records that an authorized payment will not be captured.
+func (s *PaymentStore) Abandon(ctx context.Context, paymentID string) error {
+	return updateStatus(ctx, s.db, paymentID, models.PaymentStatusAuthorized, models.PaymentStatusAbandoned, nil, nil)
+}
+
+// Capture marks an authorized payment as captured and posts the money
+// received from the customer to the ledger in the same transaction.
+func (s *PaymentStore) Capture(ctx context.Context, paymentID string, amount money.Money) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updateStatus(ctx, tx, paymentID, models.PaymentStatusAuthorized, models.PaymentStatusCaptured, nil, nil); err != nil {
+		return err
+	}
+
+	// fully discounted orders move no money
+	if !amount.IsZero() {
+		err = postLedgerTransaction(ctx, tx, paymentID, "payment captured", amount,
+			models.LedgerAccountProviderClearing, models.LedgerAccountCustomerPayments)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateRefund stores a refund request of a captured payment before the
+// provider is called. It returns false without storing anything if a refund
+// with the same ID was already requested.
+func (s *PaymentStore) CreateRefund(ctx context.Context, refund *models.Refund) (bool, error) {
+	query := `
+		INSERT INTO refunds (id, payment_id, order_id, amount, currency, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING created_at, updated_at
+	`
+
+	err := s.db.QueryRow(ctx, query, refund.ID, refund.PaymentID, refund.OrderID, refund.Amount.Amount, refund.Amount.Currency, models.RefundStatusPending).
+		Scan(&refund.CreatedAt, &refund.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	refund.Status = models.RefundStatusPending
+
+	return true, nil
+}
+
+// CompleteRefund marks a pending refund as succeeded, adds it to the refunded
+// amount of its payment and posts the money given back to the customer to the
+// ledger in the same transaction. The payment becomes refunded once nothing
+// is left of it.
+func (s *PaymentStore) CompleteRefund(ctx context.Context, refundID string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	refundQuery := `
+		UPDATE refunds
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING payment_id, amount, currency
+	`
+
+	var paymentID string
+	var amount money.Money
+	err = tx.QueryRow(ctx, refundQuery, models.RefundStatusSucceeded, refundID, models.RefundStatusPending).
+		Scan(&paymentID, &amount.Amount, &amount.Currency)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInvalidRefundStatus
+	}
+	if err != nil {
+		return err
+	}
+
+	paymentQuery := `
+		UPDATE payments
+		SET refunded_amount = refunded_amount + $1,
+			status = CASE WHEN refunded_amount + $1 = amount THEN $2 ELSE status END,
+			updated_at = NOW()
+		WHERE id = $3 AND status = $4 AND refunded_amount + $1 <= amount
+	`
+
+	tag, err := tx.Exec(ctx, paymentQuery, amount.Amount, models.PaymentStatusRefunded, paymentID, models.PaymentStatusCaptured)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidPaymentStatus
+	}
+
+	err = postLedgerTransaction(ctx, tx, paymentID, "payment refunded", amount,
+		models.LedgerAccountCustomerPayments, models.LedgerAccountProviderClearing)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FailRefund records why the provider did not give a pending refund back.
+func (s *PaymentStore) FailRefund(ctx context.Context, refundID, reason string) error {
+	query := `
+		UPDATE refunds
+		SET status = $1, failure_reason = $2, updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`
+
+	tag, err := s.db.Exec(ctx, query, models.RefundStatusFailed, reason, refundID, models.RefundStatusPending)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidRefundStatus
+	}
+
+	return nil
+}
+
+// GetLedgerEntries returns the ledger entries of a payment in posting order.
+func (s *PaymentStore) GetLedgerEntries(ctx context.Context, paymentID string) ([]models.LedgerEntry, error) {
+	query := `
+		SELECT id, transaction_id, payment_id, account, direction, amount, currency, description, created_at
+		FROM ledger_entries
+		WHERE payment_id = $1
+		ORDER BY id ASC
+	`
+
+	rows, err := s.db.Query(ctx, query, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.LedgerEntry, error) {
+		var entry models.LedgerEntry
+		err := row.Scan(
+			&entry.ID,
+			&entry.TransactionID,
+			&entry.PaymentID,
+			&entry.Account,
+			&entry.Direction,
+			&entry.Amount.Amount,
+			&entry.Amount.Currency,
+			&entry.Description,
+			&entry.CreatedAt,
+		)
+		return entry, err
+	})
+}
+
+// execer is implemented by both the pool and a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// updateStatus moves the payment from status from to status to, failing with
+// ErrInvalidPaymentStatus if it is not in status from. Non-nil
+// providerPaymentID and failureReason are stored too.
+func updateStatus(ctx context.Context, db execer, paymentID, from, to string, providerPaymentID, failureReason *string) error {
+	query := `
+		UPDATE payments
+		SET status = $1,
+			provider_payment_id = COALESCE($2, provider_payment_id),
+			failure_reason = COALESCE($3, failure_reason),
+			updated_at = NOW()
+		WHERE id = $4 AND status = $5
+	`
+
+	tag, err := db.Exec(ctx, query, to, providerPaymentID, failureReason, paymentID, from)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrInvalidPaymentStatus
+	}
+
+	return nil
+}
+
+// postLedgerTransaction moves amount from creditAccount to debitAccount as
+// one balanced posting of two entries.
+func postLedgerTransaction(ctx context.Context, tx pgx.Tx, paymentID, description string, amount money.Money, debitAccount, creditAccount string) error {
+	if amount.Amount <= 0 {
+		return fmt.Errorf("ledger amount must be positive, got %s", amount)
+	}
+
+	var transactionID string
+	if err := tx.QueryRow(ctx, "SELECT gen_random_uuid()").Scan(&transactionID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ledger_entries (transaction_id, payment_id, account, direction, amount, currency, description)
+		VALUES ($1, $2, $3, $4, $6, $7, $8), ($1, $2, $5, $9, $6, $7, $8)
+	`
+
+	_, err := tx.Exec(ctx, query, transactionID, paymentID, debitAccount, models.LedgerDirectionDebit, creditAccount,
+		amount.Amount, amount.Currency, description, models.LedgerDirectionCredit)
+
+	return err
+}
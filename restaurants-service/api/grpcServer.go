@@ -6,6 +6,8 @@ import (
 
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type GrpcServer struct {
@@ -18,9 +20,13 @@ func NewGrpcServer(s *store.MenuItemStore) *GrpcServer {
 }
 
 func (s *GrpcServer) GetMenuItems(ctx context.Context, req *pb.GetMenuItemsRequest) (*pb.GetMenuItemsResponse, error) {
-	slog.Info("received gRPC request", "menu_items", req.MenuItemIds)
+	slog.Info("received gRPC request", "restaurant_id", req.RestaurantId, "menu_items", req.MenuItemIds)
 
-	items, err := s.menuItemStore.GetByIDs(ctx, req.MenuItemIds)
+	if req.RestaurantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "restaurant_id is required")
+	}
+
+	items, err := s.menuItemStore.GetByIDs(ctx, req.RestaurantId, req.MenuItemIds)
 	if err != nil {
 		slog.Error("failed to get menu items from store", "error", err)
 		return nil, err
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/MatTwix/Food-Delivery-Agregator/common/auth"
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/handlers"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/messaging"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/middleware"
@@ -13,7 +14,7 @@ import (
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
-func SetupRoutes(restaurantStore *store.RestaurantStore, menuItemStore *store.MenuItemStore, kafkaProducer *messaging.Producer) *chi.Mux {
+func SetupRoutes(restaurantStore *store.RestaurantStore, menuItemStore *store.MenuItemStore, ordersClient pb.OrderServiceClient, kafkaProducer *messaging.Producer) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(chiMiddleware.Logger)
@@ -24,6 +25,7 @@ func SetupRoutes(restaurantStore *store.RestaurantStore, menuItemStore *store.Me
 	})
 
 	restaurantHandler := handlers.NewRestaurantHandler(restaurantStore, kafkaProducer)
+	orderHandler := handlers.NewOrderHandler(ordersClient, kafkaProducer)
 
 	r.Route("/restaurants", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
@@ -39,6 +41,17 @@ func SetupRoutes(restaurantStore *store.RestaurantStore, menuItemStore *store.Me
 
 			r.Put("/{id}", restaurantHandler.UpdateRestaurant)
 			r.Delete("/{id}", restaurantHandler.DeleteRestaurant)
+
+			r.Put("/{id}/staff/{userId}", restaurantHandler.AddStaff)
+			r.Delete("/{id}/staff/{userId}", restaurantHandler.RemoveStaff)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AuthorizeStaffOrRoles(restaurantStore.IsStaff, auth.RoleAdmin, auth.RoleManager))
+			r.Post("/{id}/orders/{orderId}/accept", orderHandler.AcceptOrder)
+			r.Post("/{id}/orders/{orderId}/reject", orderHandler.RejectOrder)
+			r.Post("/{id}/orders/{orderId}/preparing", orderHandler.MarkOrderPreparing)
+			r.Post("/{id}/orders/{orderId}/ready_for_pickup", orderHandler.MarkOrderReadyForPickup)
 		})
 	})
 
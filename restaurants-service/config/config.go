@@ -24,6 +24,11 @@ type Config struct {
 			RestaurantCreated string `mapstructure:"restaurant_created"`
 			RestaurantUpdated string `mapstructure:"restaurant_updated"`
 			RestaurantDeleted string `mapstructure:"restaurant_deleted"`
+
+			OrderAccepted       string `mapstructure:"order_accepted"`
+			OrderRejected       string `mapstructure:"order_rejected"`
+			OrderPreparing      string `mapstructure:"order_preparing"`
+			OrderReadyForPickup string `mapstructure:"order_ready_for_pickup"`
 		} `mapstructure:"topics"`
 	} `mapstructure:"kafka"`
 }
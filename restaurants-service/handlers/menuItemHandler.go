@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/models"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/store"
@@ -15,15 +16,15 @@ type MenuItemHandler struct {
 }
 
 type MenuItemInputCreate struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description,omitempty"`
-	Price       int    `json:"price" validate:"required"`
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description,omitempty"`
+	Price       money.Money `json:"price"`
 }
 
 type MenuItemInputUpdate struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description,omitempty"`
-	Price       int    `json:"price" validate:"required"`
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description,omitempty"`
+	Price       money.Money `json:"price"`
 }
 
 func NewMenuItemHandler(s *store.MenuItemStore) *MenuItemHandler {
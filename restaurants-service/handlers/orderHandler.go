@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/config"
+	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/messaging"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Order statuses owned by orders-service that the restaurant actions below
+// start from. orders-service validates every transition again when it
+// consumes the event; checking here only gives the restaurant early feedback.
+const (
+	orderStatusPaid      = "paid"
+	orderStatusAccepted  = "accepted"
+	orderStatusPreparing = "preparing"
+)
+
+type OrderHandler struct {
+	ordersClient pb.OrderServiceClient
+	producer     *messaging.Producer
+}
+
+type acceptOrderInput struct {
+	PreparationMinutes int `json:"preparation_minutes" validate:"required,gt=0,lte=240"`
+}
+
+type rejectOrderInput struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type OrderMessage struct {
+	OrderID      string `json:"order_id"`
+	RestaurantID string `json:"restaurant_id"`
+}
+
+type OrderAcceptedMessage struct {
+	OrderID          string    `json:"order_id"`
+	RestaurantID     string    `json:"restaurant_id"`
+	EstimatedReadyAt time.Time `json:"estimated_ready_at"`
+}
+
+type OrderRejectedMessage struct {
+	OrderID      string `json:"order_id"`
+	RestaurantID string `json:"restaurant_id"`
+	Reason       string `json:"reason"`
+}
+
+func NewOrderHandler(ordersClient pb.OrderServiceClient, p *messaging.Producer) *OrderHandler {
+	return &OrderHandler{
+		ordersClient: ordersClient,
+		producer:     p,
+	}
+}
+
+func (h *OrderHandler) AcceptOrder(w http.ResponseWriter, r *http.Request) {
+	var input acceptOrderInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orderID, restaurantID, ok := h.checkOrder(w, r, orderStatusPaid)
+	if !ok {
+		return
+	}
+
+	h.publish(w, r, messaging.OrderAcceptedTopic, orderID, OrderAcceptedMessage{
+		OrderID:          orderID,
+		RestaurantID:     restaurantID,
+		EstimatedReadyAt: time.Now().Add(time.Duration(input.PreparationMinutes) * time.Minute).UTC(),
+	})
+}
+
+func (h *OrderHandler) RejectOrder(w http.ResponseWriter, r *http.Request) {
+	var input rejectOrderInput
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := config.Validator.Struct(&input); err != nil {
+		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	orderID, restaurantID, ok := h.checkOrder(w, r, orderStatusPaid)
+	if !ok {
+		return
+	}
+
+	h.publish(w, r, messaging.OrderRejectedTopic, orderID, OrderRejectedMessage{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+		Reason:       input.Reason,
+	})
+}
+
+func (h *OrderHandler) MarkOrderPreparing(w http.ResponseWriter, r *http.Request) {
+	orderID, restaurantID, ok := h.checkOrder(w, r, orderStatusAccepted)
+	if !ok {
+		return
+	}
+
+	h.publish(w, r, messaging.OrderPreparingTopic, orderID, OrderMessage{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+	})
+}
+
+func (h *OrderHandler) MarkOrderReadyForPickup(w http.ResponseWriter, r *http.Request) {
+	orderID, restaurantID, ok := h.checkOrder(w, r, orderStatusAccepted, orderStatusPreparing)
+	if !ok {
+		return
+	}
+
+	h.publish(w, r, messaging.OrderReadyForPickupTopic, orderID, OrderMessage{
+		OrderID:      orderID,
+		RestaurantID: restaurantID,
+	})
+}
+
+// checkOrder makes sure the order from the URL belongs to the restaurant from
+// the URL and is in one of the given statuses. It writes the error response
+// itself and reports whether the request may go on.
+func (h *OrderHandler) checkOrder(w http.ResponseWriter, r *http.Request, allowedStatuses ...string) (orderID, restaurantID string, ok bool) {
+	restaurantID = chi.URLParam(r, "id")
+	orderID = chi.URLParam(r, "orderId")
+
+	resp, err := h.ordersClient.GetOrderRestaurant(r.Context(), &pb.GetOrderRestaurantRequest{OrderId: orderID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			http.Error(w, "Order not found", http.StatusNotFound)
+			return "", "", false
+		}
+		slog.Error("failed to get order restaurant", "order_id", orderID, "error", err)
+		http.Error(w, "Error getting order", http.StatusInternalServerError)
+		return "", "", false
+	}
+
+	// orders of other restaurants are reported as missing rather than forbidden
+	if resp.RestaurantId != restaurantID {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return "", "", false
+	}
+
+	if !slices.Contains(allowedStatuses, resp.Status) {
+		http.Error(w, fmt.Sprintf("Order is %s", resp.Status), http.StatusConflict)
+		return "", "", false
+	}
+
+	return orderID, restaurantID, true
+}
+
+// publish sends the event and answers 202: the order status changes once
+// orders-service consumes it.
+func (h *OrderHandler) publish(w http.ResponseWriter, r *http.Request, topic, orderID string, event any) {
+	eventBody, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("failed to marshal event for Kafka", "error", err)
+		http.Error(w, "Error processing order", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.producer.Produce(r.Context(), topic, []byte(orderID), eventBody); err != nil {
+		http.Error(w, "Error processing order", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(event)
+}
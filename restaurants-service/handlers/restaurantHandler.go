@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/hours"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/messaging"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/models"
@@ -22,6 +26,32 @@ type restaurantsInput struct {
 	Name        string `json:"name" validate:"required"`
 	Address     string `json:"address" validate:"required"`
 	PhoneNumber string `json:"phone_number"`
+	// Timezone defaults to UTC.
+	Timezone     string     `json:"timezone"`
+	OpeningHours hours.Week `json:"opening_hours" validate:"dive"`
+	// Latitude and Longitude are optional, but only together.
+	Latitude  *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// validate checks what the struct tags cannot and fills in defaults.
+func (input *restaurantsInput) validate() error {
+	if err := config.Validator.Struct(input); err != nil {
+		return err
+	}
+
+	if input.Timezone == "" {
+		input.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(input.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", input.Timezone)
+	}
+
+	if input.OpeningHours == nil {
+		input.OpeningHours = hours.Week{}
+	}
+
+	return input.OpeningHours.Validate()
 }
 
 type DeletionMessage struct {
@@ -69,16 +99,20 @@ func (h *RestaurantHandler) CreateRestaurant(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := config.Validator.Struct(&input); err != nil {
+	if err := input.validate(); err != nil {
 		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	restaurant := models.Restaurant{
-		OwnerID:     input.OwnerID,
-		Name:        input.Name,
-		Address:     input.Address,
-		PhoneNumber: input.PhoneNumber,
+		OwnerID:      input.OwnerID,
+		Name:         input.Name,
+		Address:      input.Address,
+		PhoneNumber:  input.PhoneNumber,
+		Timezone:     input.Timezone,
+		OpeningHours: input.OpeningHours,
+		Latitude:     input.Latitude,
+		Longitude:    input.Longitude,
 	}
 
 	if err := h.store.Create(r.Context(), &restaurant); err != nil {
@@ -86,11 +120,8 @@ func (h *RestaurantHandler) CreateRestaurant(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	eventBody, err := json.Marshal(restaurant)
-	if err != nil {
-		slog.Error("failed to marshal restaurant for Kafka event", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.RestaurantCreatedTopic, []byte(restaurant.ID), eventBody)
+	if !h.publish(w, r, messaging.RestaurantCreatedTopic, restaurant.ID, restaurant) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -106,7 +137,7 @@ func (h *RestaurantHandler) UpdateRestaurant(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := config.Validator.Struct(&input); err != nil {
+	if err := input.validate(); err != nil {
 		http.Error(w, "Validation error: "+err.Error(), http.StatusBadRequest)
 		return
 	}
@@ -114,10 +145,14 @@ func (h *RestaurantHandler) UpdateRestaurant(w http.ResponseWriter, r *http.Requ
 	id := chi.URLParam(r, "id")
 
 	restaurant := models.Restaurant{
-		ID:          id,
-		Name:        input.Name,
-		Address:     input.Address,
-		PhoneNumber: input.PhoneNumber,
+		ID:           id,
+		Name:         input.Name,
+		Address:      input.Address,
+		PhoneNumber:  input.PhoneNumber,
+		Timezone:     input.Timezone,
+		OpeningHours: input.OpeningHours,
+		Latitude:     input.Latitude,
+		Longitude:    input.Longitude,
 	}
 
 	if err := h.store.Update(r.Context(), &restaurant); err != nil {
@@ -125,11 +160,8 @@ func (h *RestaurantHandler) UpdateRestaurant(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	eventBody, err := json.Marshal(restaurant)
-	if err != nil {
-		slog.Error("failed to marshal restaurant for Kafka event", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.RestaurantUpdatedTopic, []byte(restaurant.ID), eventBody)
+	if !h.publish(w, r, messaging.RestaurantUpdatedTopic, restaurant.ID, restaurant) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -149,14 +181,71 @@ func (h *RestaurantHandler) DeleteRestaurant(w http.ResponseWriter, r *http.Requ
 		ID: id,
 	}
 
-	eventBody, err := json.Marshal(message)
+	if !h.publish(w, r, messaging.RestaurantDeletedTopic, message.ID, message) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode("Restaurant deleted!")
+}
+
+func (h *RestaurantHandler) AddStaff(w http.ResponseWriter, r *http.Request) {
+	h.changeStaff(w, r, h.store.AddStaff)
+}
+
+func (h *RestaurantHandler) RemoveStaff(w http.ResponseWriter, r *http.Request) {
+	h.changeStaff(w, r, h.store.RemoveStaff)
+}
+
+// publish sends a restaurant event, which orders-service keeps its
+// restaurant cache from. It answers 500 itself and reports false if the event
+// could not be sent.
+func (h *RestaurantHandler) publish(w http.ResponseWriter, r *http.Request, topic, key string, event any) bool {
+	eventBody, err := json.Marshal(event)
 	if err != nil {
 		slog.Error("failed to marshal restaurant for Kafka event", "error", err)
-	} else {
-		h.producer.Produce(r.Context(), messaging.RestaurantDeletedTopic, []byte(message.ID), eventBody)
+		http.Error(w, "Error publishing restaurant event", http.StatusInternalServerError)
+		return false
+	}
+
+	if err := h.producer.Produce(r.Context(), topic, []byte(key), eventBody); err != nil {
+		http.Error(w, "Error publishing restaurant event", http.StatusInternalServerError)
+		return false
+	}
+
+	return true
+}
+
+// changeStaff applies a staff change and publishes the restaurant with its
+// new staff as restaurant.updated, so orders-service can authorize staff.
+func (h *RestaurantHandler) changeStaff(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, restaurantID, userID string) error) {
+	id := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userId")
+
+	if err := config.Validator.Var(userID, "uuid"); err != nil {
+		http.Error(w, "Validation error: user id must be a UUID", http.StatusBadRequest)
+		return
+	}
+
+	if err := change(r.Context(), id, userID); err != nil {
+		slog.Error("failed to change restaurant staff", "restaurant_id", id, "user_id", userID, "error", err)
+		http.Error(w, "Error changing restaurant staff", http.StatusInternalServerError)
+		return
+	}
+
+	restaurant, err := h.store.GetByID(r.Context(), id)
+	if err != nil {
+		slog.Error("failed to get restaurant", "restaurant_id", id, "error", err)
+		http.Error(w, "Error getting restaurant by ID", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.publish(w, r, messaging.RestaurantUpdatedTopic, restaurant.ID, restaurant) {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode("Restaurant deleted!")
+	json.NewEncoder(w).Encode(restaurant)
 }
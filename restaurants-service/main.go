@@ -12,6 +12,7 @@ import (
 
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/api"
+	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/clients"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/config"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/database"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/messaging"
@@ -46,7 +47,9 @@ func main() {
 	grpcServer := grpc.NewServer()
 	pb.RegisterRestaurantServiceServer(grpcServer, api.NewGrpcServer(store.NewMenuItemStore(db)))
 
-	router := api.SetupRoutes(restaurantStore, menuItemStore, kafkaProducer)
+	ordersClient := clients.NewOrdersServiceClient()
+
+	router := api.SetupRoutes(restaurantStore, menuItemStore, ordersClient, kafkaProducer)
 	httpServer := &http.Server{
 		Addr:    ":" + config.Cfg.HTTP.Port,
 		Handler: router,
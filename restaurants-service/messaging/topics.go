@@ -15,6 +15,11 @@ var (
 	RestaurantCreatedTopic string
 	RestaurantUpdatedTopic string
 	RestaurantDeletedTopic string
+
+	OrderAcceptedTopic       string
+	OrderRejectedTopic       string
+	OrderPreparingTopic      string
+	OrderReadyForPickupTopic string
 )
 
 var Topics []string
@@ -24,10 +29,20 @@ func InitTopicsNames() {
 	RestaurantUpdatedTopic = config.Cfg.Kafka.Topics.RestaurantUpdated
 	RestaurantDeletedTopic = config.Cfg.Kafka.Topics.RestaurantDeleted
 
+	OrderAcceptedTopic = config.Cfg.Kafka.Topics.OrderAccepted
+	OrderRejectedTopic = config.Cfg.Kafka.Topics.OrderRejected
+	OrderPreparingTopic = config.Cfg.Kafka.Topics.OrderPreparing
+	OrderReadyForPickupTopic = config.Cfg.Kafka.Topics.OrderReadyForPickup
+
 	Topics = []string{
 		RestaurantCreatedTopic,
 		RestaurantUpdatedTopic,
 		RestaurantDeletedTopic,
+
+		OrderAcceptedTopic,
+		OrderRejectedTopic,
+		OrderPreparingTopic,
+		OrderReadyForPickupTopic,
 	}
 }
 
@@ -32,6 +32,43 @@ func Authorize(allowedRoles ...auth.Role) func(http.Handler) http.Handler {
 	}
 }
 
+// AuthorizeStaffOrRoles lets through the owner and staff of the restaurant
+// from the URL, as well as users with one of the given roles.
+func AuthorizeStaffOrRoles(isStaff func(ctx context.Context, restaurantID, userID string) (bool, error), allowedRoles ...auth.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID := r.Header.Get("X-User-Id")
+			userRole := r.Header.Get("X-User-Role")
+
+			isAllowed := false
+			for _, role := range allowedRoles {
+				if userRole == role.String() {
+					isAllowed = true
+					break
+				}
+			}
+
+			if !isAllowed && userID != "" {
+				restaurantID := chi.URLParam(r, "id")
+				staff, err := isStaff(r.Context(), restaurantID, userID)
+				if err != nil {
+					slog.Error("failed to check restaurant staff", "error", err)
+					http.Error(w, "Failed to check restaurant staff", http.StatusInternalServerError)
+					return
+				}
+				isAllowed = staff
+			}
+
+			if !isAllowed {
+				http.Error(w, "Forbidden: insufficient permisions", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func AuthorizeOwnerOrRoles(getOwnerID func(ctx context.Context, targetID string) (string, error), allowedRoles ...auth.Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddRestaurantsLocation adds the restaurant coordinates. Existing
+// restaurants get none until they are updated.
+func AddRestaurantsLocation(db *pgxpool.Pool) {
+	ctx := context.Background()
+
+	_, err := db.Exec(ctx, `
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+	`)
+	if err != nil {
+		slog.Error("failed to add restaurants location columns", "error", err)
+		os.Exit(1)
+	}
+}
@@ -33,7 +33,8 @@ func CreateMenuItemsTable(db *pgxpool.Pool) {
 				restaurant_id UUID NOT NULL,
 				name VARCHAR(255) NOT NULL,
 				description TEXT,
-				price NUMERIC(10, 2) NOT NULL,
+				price BIGINT NOT NULL,
+				currency VARCHAR(3) NOT NULL,
 				created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 				updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 				CONSTRAINT fk_restaurant FOREIGN KEY (restaurant_id) REFERENCES restaurants(id) ON DELETE CASCADE
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MigrateMenuItemsPriceToMinorUnits converts menu_items created with a
+// NUMERIC(10, 2) price to integer minor units with an explicit currency.
+// Existing prices are assumed to be USD.
+func MigrateMenuItemsPriceToMinorUnits(db *pgxpool.Pool) {
+	ctx := context.Background()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		slog.Error("failed to start transaction", "error", err)
+		os.Exit(1)
+	}
+	defer tx.Rollback(ctx)
+
+	var dataType string
+	err = tx.QueryRow(ctx,
+		"SELECT data_type FROM information_schema.columns WHERE table_name = 'menu_items' AND column_name = 'price';").
+		Scan(&dataType)
+	if err != nil {
+		slog.Error("failed to check menu_items price column type", "error", err)
+		os.Exit(1)
+	}
+
+	if dataType != "numeric" {
+		return
+	}
+
+	_, err = tx.Exec(ctx, `
+		ALTER TABLE menu_items ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+		ALTER TABLE menu_items ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'USD';
+		ALTER TABLE menu_items ALTER COLUMN currency DROP DEFAULT;
+	`)
+	if err != nil {
+		slog.Error("failed to migrate menu_items prices to minor units", "error", err)
+		os.Exit(1)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("failed to commit transaction", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("menu_items prices migrated to minor units")
+}
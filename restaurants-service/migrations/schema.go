@@ -4,5 +4,9 @@ import "github.com/jackc/pgx/v5/pgxpool"
 
 func Migrate(db *pgxpool.Pool) {
 	CreateRestaurantsTable(db)
+	AddRestaurantsOpeningHours(db)
+	AddRestaurantsLocation(db)
+	CreateRestaurantStaffTable(db)
 	CreateMenuItemsTable(db)
+	MigrateMenuItemsPriceToMinorUnits(db)
 }
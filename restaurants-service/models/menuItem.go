@@ -1,13 +1,17 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
+)
 
 type MenuItem struct {
-	ID           string    `json:"id"`
-	RestaurantID string    `json:"restaurant_id"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description,omitempty"`
-	Price        int       `json:"price"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           string      `json:"id"`
+	RestaurantID string      `json:"restaurant_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description,omitempty"`
+	Price        money.Money `json:"price"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/MatTwix/Food-Delivery-Agregator/common/money"
 	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
 	"github.com/MatTwix/Food-Delivery-Agregator/restaurants-service/models"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -20,7 +21,7 @@ func NewMenuItemStore(db *pgxpool.Pool) *MenuItemStore {
 func (s *MenuItemStore) GetAll(ctx context.Context) ([]models.MenuItem, error) {
 	query := `
 		SELECT
-		id, restaurant_id, name, description, price, created_at, updated_at
+		id, restaurant_id, name, description, price, currency, created_at, updated_at
 		FROM
 		menu_items
 	`
@@ -40,7 +41,8 @@ func (s *MenuItemStore) GetAll(ctx context.Context) ([]models.MenuItem, error) {
 			&menuItem.RestaurantID,
 			&menuItem.Name,
 			&menuItem.Description,
-			&menuItem.Price,
+			&menuItem.Price.Amount,
+			&menuItem.Price.Currency,
 			&menuItem.CreatedAt,
 			&menuItem.UpdatedAt,
 		); err != nil {
@@ -52,9 +54,11 @@ func (s *MenuItemStore) GetAll(ctx context.Context) ([]models.MenuItem, error) {
 	return menuItems, nil
 }
 
-func (s *MenuItemStore) GetByIDs(ctx context.Context, itemIDs []string) ([]*pb.MenuItem, error) {
-	query := "SELECT id, name, price FROM menu_items WHERE id = ANY($1)"
-	rows, err := s.db.Query(ctx, query, itemIDs)
+// GetByIDs returns the menu items of the restaurant with the given IDs.
+// Items of other restaurants are left out.
+func (s *MenuItemStore) GetByIDs(ctx context.Context, restaurantID string, itemIDs []string) ([]*pb.MenuItem, error) {
+	query := "SELECT id, name, COALESCE(description, ''), price, currency FROM menu_items WHERE restaurant_id = $1 AND id = ANY($2)"
+	rows, err := s.db.Query(ctx, query, restaurantID, itemIDs)
 
 	if err != nil {
 		return nil, err
@@ -64,11 +68,11 @@ func (s *MenuItemStore) GetByIDs(ctx context.Context, itemIDs []string) ([]*pb.M
 	var items []*pb.MenuItem
 	for rows.Next() {
 		var item pb.MenuItem
-		var price float64
-		if err := rows.Scan(&item.Id, &item.Name, &price); err != nil {
+		var price money.Money
+		if err := rows.Scan(&item.Id, &item.Name, &item.Description, &price.Amount, &price.Currency); err != nil {
 			return nil, err
 		}
-		item.Price = price
+		item.Price = money.ToProto(price)
 		items = append(items, &item)
 	}
 
@@ -78,7 +82,7 @@ func (s *MenuItemStore) GetByIDs(ctx context.Context, itemIDs []string) ([]*pb.M
 func (s *MenuItemStore) GetByRestaurantID(ctx context.Context, restauarntID string) ([]models.MenuItem, error) {
 	query := `
 		SELECT
-		id, restaurant_id, name, description, price, created_at, updated_at
+		id, restaurant_id, name, description, price, currency, created_at, updated_at
 		FROM
 		menu_items
 		WHERE 
@@ -100,7 +104,8 @@ func (s *MenuItemStore) GetByRestaurantID(ctx context.Context, restauarntID stri
 			&menuItem.RestaurantID,
 			&menuItem.Name,
 			&menuItem.Description,
-			&menuItem.Price,
+			&menuItem.Price.Amount,
+			&menuItem.Price.Currency,
 			&menuItem.CreatedAt,
 			&menuItem.UpdatedAt,
 		); err != nil {
@@ -130,13 +135,13 @@ func (s *MenuItemStore) GetRestaurantOwnerID(ctx context.Context, targetID strin
 func (s *MenuItemStore) Create(ctx context.Context, menuItem *models.MenuItem) error {
 	query := `
 		INSERT INTO menu_items
-		(restaurant_id, name, description, price)
+		(restaurant_id, name, description, price, currency)
 		VALUES
-		($1, $2, $3, $4)
+		($1, $2, $3, $4, $5)
 		RETURNING id, created_at, updated_at
 	`
 
-	err := s.db.QueryRow(ctx, query, menuItem.RestaurantID, menuItem.Name, menuItem.Description, menuItem.Price).
+	err := s.db.QueryRow(ctx, query, menuItem.RestaurantID, menuItem.Name, menuItem.Description, menuItem.Price.Amount, menuItem.Price.Currency).
 		Scan(&menuItem.ID, &menuItem.CreatedAt, &menuItem.UpdatedAt)
 
 	return err
@@ -145,12 +150,12 @@ func (s *MenuItemStore) Create(ctx context.Context, menuItem *models.MenuItem) e
 func (s *MenuItemStore) Update(ctx context.Context, menuItem *models.MenuItem) error {
 	query := `
 		UPDATE menu_items
-		SET name = $1, description = $2, price = $3, updated_at = NOW()
-		WHERE id = $4
+		SET name = $1, description = $2, price = $3, currency = $4, updated_at = NOW()
+		WHERE id = $5
 		RETURNING id, restaurant_id, created_at, updated_at
 	`
 
-	err := s.db.QueryRow(ctx, query, menuItem.Name, menuItem.Description, menuItem.Price, menuItem.ID).
+	err := s.db.QueryRow(ctx, query, menuItem.Name, menuItem.Description, menuItem.Price.Amount, menuItem.Price.Currency, menuItem.ID).
 		Scan(&menuItem.ID, &menuItem.RestaurantID, &menuItem.CreatedAt, &menuItem.UpdatedAt)
 
 	return err
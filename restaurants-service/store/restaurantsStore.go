@@ -19,7 +19,8 @@ func NewRestaurantsStore(db *pgxpool.Pool) *RestaurantStore {
 func (s *RestaurantStore) GetAll(ctx context.Context) ([]models.Restaurant, error) {
 	query := `
 		SELECT 
-		id, owner_id, name, address, phone_number, created_at, updated_at 
+		id, owner_id, name, address, phone_number, timezone, opening_hours, latitude, longitude, created_at, updated_at,
+		ARRAY(SELECT user_id::text FROM restaurant_staff WHERE restaurant_id = restaurants.id ORDER BY created_at)
 		FROM
 		restaurants
 	`
@@ -38,8 +39,13 @@ func (s *RestaurantStore) GetAll(ctx context.Context) ([]models.Restaurant, erro
 			&restaurant.Name,
 			&restaurant.Address,
 			&restaurant.PhoneNumber,
+			&restaurant.Timezone,
+			&restaurant.OpeningHours,
+			&restaurant.Latitude,
+			&restaurant.Longitude,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
+			&restaurant.StaffIDs,
 		); err != nil {
 			return nil, err
 		}
@@ -52,7 +58,8 @@ func (s *RestaurantStore) GetAll(ctx context.Context) ([]models.Restaurant, erro
 func (s *RestaurantStore) GetByID(ctx context.Context, id string) (models.Restaurant, error) {
 	query := `
 		SELECT
-		owner_id, name, address, phone_number, created_at, updated_at
+		owner_id, name, address, phone_number, timezone, opening_hours, latitude, longitude, created_at, updated_at,
+		ARRAY(SELECT user_id::text FROM restaurant_staff WHERE restaurant_id = restaurants.id ORDER BY created_at)
 		FROM
 		restaurants
 		WHERE
@@ -69,8 +76,13 @@ func (s *RestaurantStore) GetByID(ctx context.Context, id string) (models.Restau
 			&restaurant.Name,
 			&restaurant.Address,
 			&restaurant.PhoneNumber,
+			&restaurant.Timezone,
+			&restaurant.OpeningHours,
+			&restaurant.Latitude,
+			&restaurant.Longitude,
 			&restaurant.CreatedAt,
 			&restaurant.UpdatedAt,
+			&restaurant.StaffIDs,
 		)
 
 	return restaurant, err
@@ -90,17 +102,58 @@ func (s *RestaurantStore) GetOwnerID(ctx context.Context, targetID string) (stri
 	return ownerID, err
 }
 
+// IsStaff reports whether the user is the owner or a staff member of the restaurant.
+func (s *RestaurantStore) IsStaff(ctx context.Context, restaurantID, userID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM restaurants WHERE id = $1 AND owner_id = $2
+			UNION ALL
+			SELECT 1 FROM restaurant_staff WHERE restaurant_id = $1 AND user_id = $2
+		)
+	`
+
+	var isStaff bool
+	err := s.db.QueryRow(ctx, query, restaurantID, userID).Scan(&isStaff)
+
+	return isStaff, err
+}
+
+func (s *RestaurantStore) AddStaff(ctx context.Context, restaurantID, userID string) error {
+	query := `
+		INSERT INTO restaurant_staff (restaurant_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (restaurant_id, user_id) DO NOTHING
+	`
+
+	_, err := s.db.Exec(ctx, query, restaurantID, userID)
+
+	return err
+}
+
+func (s *RestaurantStore) RemoveStaff(ctx context.Context, restaurantID, userID string) error {
+	query := `
+		DELETE FROM restaurant_staff
+		WHERE restaurant_id = $1 AND user_id = $2
+	`
+
+	_, err := s.db.Exec(ctx, query, restaurantID, userID)
+
+	return err
+}
+
 func (s *RestaurantStore) Create(ctx context.Context, restaurant *models.Restaurant) error {
 	query := `
 		INSERT INTO restaurants 
-		(owner_id, name, address, phone_number)
+		(owner_id, name, address, phone_number, timezone, opening_hours, latitude, longitude)
 		VALUES
-		($1, $2, $3, $4)
+		($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id, created_at, updated_at`
 
-	err := s.db.QueryRow(ctx, query, restaurant.OwnerID, restaurant.Name, restaurant.Address, restaurant.PhoneNumber).
+	err := s.db.QueryRow(ctx, query, restaurant.OwnerID, restaurant.Name, restaurant.Address, restaurant.PhoneNumber, restaurant.Timezone, restaurant.OpeningHours, restaurant.Latitude, restaurant.Longitude).
 		Scan(&restaurant.ID, &restaurant.CreatedAt, &restaurant.UpdatedAt)
 
+	restaurant.StaffIDs = []string{}
+
 	return err
 }
 
@@ -108,14 +161,16 @@ func (s *RestaurantStore) Update(ctx context.Context, restaurant *models.Restaur
 	query := `
 		UPDATE restaurants
 		SET
-		name = $1, address = $2, phone_number = $3, updated_at = NOW()
+		name = $1, address = $2, phone_number = $3, timezone = $5, opening_hours = $6, latitude = $7, longitude = $8, updated_at = NOW()
 		WHERE
 		id = $4
-		RETURNING created_at, updated_at
+		RETURNING owner_id, created_at, updated_at,
+		ARRAY(SELECT user_id::text FROM restaurant_staff WHERE restaurant_id = restaurants.id ORDER BY created_at)
 	`
 
-	err := s.db.QueryRow(ctx, query, restaurant.Name, restaurant.Address, restaurant.PhoneNumber, restaurant.ID).
-		Scan(&restaurant.CreatedAt, &restaurant.UpdatedAt)
+	// owner_id and staff are returned so the update event carries the whole restaurant
+	err := s.db.QueryRow(ctx, query, restaurant.Name, restaurant.Address, restaurant.PhoneNumber, restaurant.ID, restaurant.Timezone, restaurant.OpeningHours, restaurant.Latitude, restaurant.Longitude).
+		Scan(&restaurant.OwnerID, &restaurant.CreatedAt, &restaurant.UpdatedAt, &restaurant.StaffIDs)
 
 	return err
 }
@@ -20,6 +20,8 @@ type Config struct {
 		Topics struct {
 			CourierRequested              string `mapstructure:"courier_requested"`
 			RefreshTokenDeletionRequested string `mapstructure:"refresh_token_deletion_requsted"`
+			OrderReleaseRequested         string `mapstructure:"order_release_requested"`
+			OrderExpiryRequested          string `mapstructure:"order_expiry_requested"`
 		} `mapstructure:"topics"`
 	} `mapstructure:"kafka"`
 }
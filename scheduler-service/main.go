@@ -38,8 +38,10 @@ func main() {
 
 	requestCourierJob := scheduler.NewRequestCourierJob(ordersGRPCClient, kafkaProducer)
 	deleteExpiredTokensJob := scheduler.NewDeleteExpiredTokensJob(usersGRPCClient, kafkaProducer)
+	releaseScheduledOrdersJob := scheduler.NewReleaseScheduledOrdersJob(ordersGRPCClient, kafkaProducer)
+	expireUnpaidOrdersJob := scheduler.NewExpireUnpaidOrdersJob(ordersGRPCClient, kafkaProducer)
 
-	scheduler.RegisterJobs(c, requestCourierJob, deleteExpiredTokensJob)
+	scheduler.RegisterJobs(c, requestCourierJob, deleteExpiredTokensJob, releaseScheduledOrdersJob, expireUnpaidOrdersJob)
 
 	go c.Run()
 
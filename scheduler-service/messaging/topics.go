@@ -15,6 +15,8 @@ var (
 	CourierRequestedTopic string
 
 	RefreshTokenDeletionRequestedTopic string
+	OrderReleaseRequestedTopic         string
+	OrderExpiryRequestedTopic          string
 )
 
 var Topics []string
@@ -22,10 +24,14 @@ var Topics []string
 func InitTopicsNames() {
 	CourierRequestedTopic = config.Cfg.Kafka.Topics.CourierRequested
 	RefreshTokenDeletionRequestedTopic = config.Cfg.Kafka.Topics.RefreshTokenDeletionRequested
+	OrderReleaseRequestedTopic = config.Cfg.Kafka.Topics.OrderReleaseRequested
+	OrderExpiryRequestedTopic = config.Cfg.Kafka.Topics.OrderExpiryRequested
 
 	Topics = []string{
 		CourierRequestedTopic,
 		RefreshTokenDeletionRequestedTopic,
+		OrderReleaseRequestedTopic,
+		OrderExpiryRequestedTopic,
 	}
 }
 
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/scheduler-service/messaging"
+)
+
+// ExpireUnpaidOrdersJob asks orders-service to expire orders left pending or
+// payment_failed for longer than the unpaid order TTL of orders-service.
+type ExpireUnpaidOrdersJob struct {
+	spec         string
+	limit        int32
+	ordersClient pb.OrderServiceClient
+	producer     *messaging.Producer
+}
+
+func NewExpireUnpaidOrdersJob(ordersClient pb.OrderServiceClient, p *messaging.Producer) *ExpireUnpaidOrdersJob {
+	return &ExpireUnpaidOrdersJob{
+		spec:         "@every 5m",
+		limit:        100,
+		ordersClient: ordersClient,
+		producer:     p,
+	}
+}
+
+func (j *ExpireUnpaidOrdersJob) Spec() string {
+	return j.spec
+}
+
+func (j *ExpireUnpaidOrdersJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	slog.Info("requesting unpaid orders to expire")
+
+	resp, err := j.ordersClient.GetExpiredUnpaidOrders(ctx, &pb.GetExpiredUnpaidOrdersRequest{Limit: j.limit})
+	if err != nil {
+		slog.Error("failed to fetch unpaid orders", "error", err)
+		return
+	}
+
+	if len(resp.Orders) == 0 {
+		slog.Info("there are no unpaid orders to expire")
+		return
+	}
+
+	for _, order := range resp.Orders {
+		slog.Info("expiring unpaid order", "orderID", order.Id, "status", order.Status, "unpaid_since", time.Unix(order.UnpaidSince, 0))
+
+		event := struct {
+			OrderID string `json:"order_id"`
+		}{OrderID: order.Id}
+
+		eventBody, err := json.Marshal(event)
+		if err != nil {
+			slog.Error("failed to marshal event", "orderID", order.Id, "error", err)
+			continue
+		}
+
+		err = j.producer.Produce(ctx, messaging.OrderExpiryRequestedTopic, []byte(order.Id), eventBody)
+		if err != nil {
+			slog.Error("failed to send order expiry requested event", "orderID", order.Id, "error", err)
+		}
+	}
+}
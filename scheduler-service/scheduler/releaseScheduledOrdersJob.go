@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	pb "github.com/MatTwix/Food-Delivery-Agregator/common/proto"
+	"github.com/MatTwix/Food-Delivery-Agregator/scheduler-service/messaging"
+)
+
+// ReleaseScheduledOrdersJob asks orders-service to release scheduled orders
+// to payment once their release time, a lead time before delivery, has come.
+type ReleaseScheduledOrdersJob struct {
+	spec         string
+	limit        int32
+	ordersClient pb.OrderServiceClient
+	producer     *messaging.Producer
+}
+
+func NewReleaseScheduledOrdersJob(ordersClient pb.OrderServiceClient, p *messaging.Producer) *ReleaseScheduledOrdersJob {
+	return &ReleaseScheduledOrdersJob{
+		spec:         "@every 1m",
+		limit:        100,
+		ordersClient: ordersClient,
+		producer:     p,
+	}
+}
+
+func (j *ReleaseScheduledOrdersJob) Spec() string {
+	return j.spec
+}
+
+func (j *ReleaseScheduledOrdersJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	slog.Info("requesting scheduled orders to release")
+
+	resp, err := j.ordersClient.GetDueScheduledOrders(ctx, &pb.GetDueScheduledOrdersRequest{Limit: j.limit})
+	if err != nil {
+		slog.Error("failed to fetch scheduled orders", "error", err)
+		return
+	}
+
+	if len(resp.Orders) == 0 {
+		slog.Info("there are no scheduled orders to release")
+		return
+	}
+
+	for _, order := range resp.Orders {
+		slog.Info("releasing scheduled order", "orderID", order.Id, "deliver_at", time.Unix(order.DeliverAt, 0))
+
+		event := struct {
+			OrderID string `json:"order_id"`
+		}{OrderID: order.Id}
+
+		eventBody, err := json.Marshal(event)
+		if err != nil {
+			slog.Error("failed to marshal event", "orderID", order.Id, "error", err)
+			continue
+		}
+
+		err = j.producer.Produce(ctx, messaging.OrderReleaseRequestedTopic, []byte(order.Id), eventBody)
+		if err != nil {
+			slog.Error("failed to send order release requested event", "orderID", order.Id, "error", err)
+		}
+	}
+}